@@ -1,36 +1,50 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
-	"github.com/joho/godotenv"
 	"github.com/p2hacks2025/pre-12/backend/internal/batch"
+	"github.com/p2hacks2025/pre-12/backend/internal/config"
 	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 func main() {
-	godotenv.Load()
-	db.Init()
+	cfg, _, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	pool, err := db.Connect(context.Background(), cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+	store := storage.Config{
+		URL:            cfg.Supabase.URL,
+		ServiceRoleKey: cfg.Supabase.ServiceRoleKey,
+	}
 
 	log.Println("Creating buckets...")
 
 	// バケットを作成（public: true でアイコンを公開アクセス可能にする）
-	if err := batch.CreateBucketIfNotExists("icons", true); err != nil {
+	if err := batch.CreateBucketIfNotExists(store, "icons", true); err != nil {
 		log.Fatal("failed to create icons bucket:", err)
 	}
 
-	if err := batch.CreateBucketIfNotExists("works", true); err != nil {
+	if err := batch.CreateBucketIfNotExists(store, "works", true); err != nil {
 		log.Fatal("failed to create works bucket:", err)
 	}
 
 	log.Println("Buckets created, starting batch processes...")
 
-	batch.InsertDummyUsers()
-	batch.InsertDummyWorks()
-	batch.UploadDefaultIcon()
-	batch.UploadIconsFromLocal()
-	batch.UploadWorksFromLocal()
-	batch.InsertDummySwipesAndMatches()
-	//batch.InsertDummyReviews()
-	batch.InsertDummyReviewsSkipEven()
+	batch.InsertDummyUsers(pool)
+	batch.InsertDummyWorks(pool)
+	batch.UploadDefaultIcon(store)
+	batch.UploadIconsFromLocal(pool, store)
+	batch.UploadWorksFromLocal(pool, store)
+	batch.InsertDummySwipesAndMatches(pool)
+	//batch.InsertDummyReviews(pool)
+	batch.InsertDummyReviewsSkipEven(pool)
 }
@@ -1,60 +1,175 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/background"
+	"github.com/p2hacks2025/pre-12/backend/internal/config"
 	"github.com/p2hacks2025/pre-12/backend/internal/db"
 	"github.com/p2hacks2025/pre-12/backend/internal/handler"
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
+	"github.com/p2hacks2025/pre-12/backend/internal/textfilter"
 )
 
 func main() {
-	godotenv.Load() // これで .env の内容が環境変数として読み込まれる
-	// DB 初期化
-	db.Init()
+	// 設定（環境変数・.env・引数）を読み込んで検証する
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	r := gin.Default()
+	// SIGINT / SIGTERM で止める（定期処理などはこの ctx で終わる）
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Flutter 用 CORS 設定
-	r.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Authorization"},
-	}))
-
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
-
-	r.GET("/supabase-health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"supabase": "ok"})
+	// DB 初期化
+	pool, err := db.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// server migrate up|down|status|baseline
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			log.Fatalf("unknown command: %s\n%s", args[0], migrateUsage)
+		}
+		if err := runMigrate(ctx, pool, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// スキーマのドリフト確認（MIGRATIONS=up なら未適用のものを適用する）
+	if err := checkMigrations(ctx, pool, cfg.Migrations); err != nil {
+		log.Fatal(err)
+	}
+
+	store := storage.Config{
+		URL:            cfg.Supabase.URL,
+		ServiceRoleKey: cfg.Supabase.ServiceRoleKey,
+	}
+
+	// 常駐する処理（終了時に止まるのを待つ）
+	workers := background.NewGroup()
+
+	// リアルタイム配信（複数インスタンス構成では Postgres の LISTEN/NOTIFY を使う）
+	var broker realtime.Broker = realtime.NewHub()
+	if cfg.Realtime.Backend == "postgres" {
+		pg := realtime.NewPGBroker(pool)
+		workers.Go("realtime listen", func(context.Context) { pg.Listen(ctx) })
+		broker = pg
+	}
+
+	// 禁止語フィルタ（埋め込みのリストに、運用で足した語を加える）
+	words := textfilter.NewDefault()
+	if cfg.TextFilter.WordsFile != "" {
+		if err := words.LoadFile(cfg.TextFilter.WordsFile); err != nil {
+			log.Fatalf("failed to load text filter words: %v", err)
+		}
+	}
+
+	repos := repository.NewPG(pool)
+	server := handler.NewServer(repos, handler.Config{
+		Storage: store,
+		Broker:  broker,
+		// 低品質なレビューの扱い（reject: 拒否 / flag: 受け付けてフラグを付ける）
+		QualityMode: cfg.Quality,
+		// 禁止語を含むテキストの扱い（reject: 拒否 / mask: 伏せ字にして通報を残す）
+		TextFilter:     words,
+		TextFilterMode: cfg.TextFilter.Mode,
 	})
 
-	r.GET("/debug/users", handler.DebugGetUsers)
-
-	r.GET("/debug/works", handler.DebugGetWorks)
+	// プッシュ通知（http: FCM 形式のエンドポイントへ送信 / fake: 送信内容をログに出すだけ）
+	switch cfg.Push.Provider {
+	case "http":
+		pusher := notify.NewHTTPPusher(cfg.Push.Endpoint, cfg.Push.ServerKey)
+		server.Notifier.Push = notify.NewDispatcher(pusher, repos.Devices)
+	case "fake":
+		pusher := notify.NewFakePusher()
+		pusher.Log = true
+		server.Notifier.Push = notify.NewDispatcher(pusher, repos.Devices)
+	}
 
-	r.POST("/sign-up", handler.Signup)
+	// レビュー期限のリマインド
+	workers.Go("review reminder", func(context.Context) { server.Notifier.RunReviewReminder(ctx, time.Hour) })
 
-	r.POST("/login", handler.Login)
+	// 猶予期間を過ぎた退会ユーザーの完全削除
+	workers.Go("account purge", func(context.Context) { service.RunAccountPurge(ctx, repos.Accounts, store, time.Hour) })
 
-	r.POST("/update-profile", handler.UpdateMyProfile)
-
-	r.GET("/me", handler.GetMyProfile)
-
-	r.GET("/my-works", handler.GetMyWorks)
-
-	r.POST("/work", handler.PostWork) // /workに修正
-
-	r.GET("/works", handler.GetWorks)
-
-	r.POST("/swipe", handler.PostSwipe)
-
-	r.GET("/matches", handler.GetMatches)
+	r := gin.Default()
 
-	r.POST("/review", handler.PostReview)
+	// エラーレスポンスとログを突き合わせるためのリクエスト ID
+	r.Use(apierror.RequestID())
 
-	r.GET("/reviews", handler.GetReceivedReviews)
+	// Flutter 用 CORS 設定
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "Accept-Language", apierror.RequestIDHeader},
+		ExposeHeaders: []string{apierror.RequestIDHeader},
+	}))
 
-	r.Run(":8080")
+	r.NoRoute(apierror.NoRoute)
+
+	server.Routes(r)
+
+	srv := &http.Server{
+		Addr:              cfg.Addr(),
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       60 * time.Second, // 画像のアップロードがあるので長め
+		WriteTimeout:      60 * time.Second, // イベント配信は接続ごとに解除する
+		IdleTimeout:       120 * time.Second,
+	}
+	// 張りっぱなしのイベント配信を閉じないと Shutdown が終わらない
+	srv.RegisterOnShutdown(server.CloseStreams)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s (profile %s)", srv.Addr, cfg.Profile)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	case <-ctx.Done():
+	}
+	stop()
+	log.Printf("shutting down (timeout %s)", cfg.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// ① 新しいリクエストを止め、処理中のリクエストを待つ
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("http shutdown: %v", err)
+	}
+	// ② レスポンス後に続けているマッチ判定・通知を待つ
+	if err := server.Tasks.Shutdown(shutdownCtx); err != nil {
+		log.Printf("background tasks did not finish: %v", err)
+	}
+	// ③ 定期処理と LISTEN の終了を待つ
+	if err := workers.Shutdown(shutdownCtx); err != nil {
+		log.Printf("workers did not stop: %v", err)
+	}
+	// ④ 使い終わった接続を閉じる
+	pool.Close()
+	log.Println("shutdown complete")
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/p2hacks2025/pre-12/backend/internal/migrate"
+	"github.com/p2hacks2025/pre-12/backend/supabase"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up                 未適用のマイグレーションをすべて適用する
+  down [N]           新しいものから N 個（省略時は 1 個）取り消す
+  status             適用状況とドリフトを表示する
+  baseline [VERSION] VERSION（省略時は最新）までを実行せずに適用済みにする
+                     supabase CLI で作ったデータベースを移すときに使う`
+
+func newMigrator(pool *pgxpool.Pool) (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(supabase.Files)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewMigrator(pool, migrations), nil
+}
+
+// runMigrate は `server migrate ...` を実行する
+func runMigrate(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	m, err := newMigrator(pool)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		for _, mig := range done {
+			log.Printf("applied %s_%s", mig.Version, mig.Name)
+		}
+		if err == nil && len(done) == 0 {
+			log.Println("already up to date")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		done, err := m.Down(ctx, steps)
+		for _, mig := range done {
+			log.Printf("rolled back %s_%s", mig.Version, mig.Name)
+		}
+		return err
+
+	case "status":
+		report, applied, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(m.Migrations(), applied, report)
+		return report.Drift()
+
+	case "baseline":
+		migrations := m.Migrations()
+		if len(migrations) == 0 {
+			return nil
+		}
+		version := migrations[len(migrations)-1].Version
+		if len(args) > 1 {
+			version = args[1]
+		}
+		done, err := m.Baseline(ctx, version)
+		for _, mig := range done {
+			log.Printf("marked %s_%s as applied", mig.Version, mig.Name)
+		}
+		return err
+	}
+
+	return errors.New(migrateUsage)
+}
+
+func printStatus(migrations []migrate.Migration, applied []migrate.Applied, report migrate.Report) {
+	appliedAt := make(map[string]string, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a.AppliedAt.Local().Format("2006-01-02 15:04:05")
+	}
+	changed := make(map[string]bool, len(report.Changed))
+	for _, mig := range report.Changed {
+		changed[mig.Version] = true
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, mig := range migrations {
+		status, ok := appliedAt[mig.Version]
+		switch {
+		case !ok:
+			status = "pending"
+		case changed[mig.Version]:
+			status = "modified (applied " + status + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", mig.Version, mig.Name, status)
+	}
+	for _, a := range report.Missing {
+		fmt.Fprintf(w, "%s\t%s\tmissing file\n", a.Version, a.Name)
+	}
+	w.Flush()
+}
+
+// checkMigrations は起動時にスキーマを確認する（MIGRATIONS で切り替え）
+//   - check（既定）: ドリフトがあれば起動しない。未適用のものはログに出すだけ
+//   - up: 未適用のものを適用してから起動する
+//   - off: 確認しない
+func checkMigrations(ctx context.Context, pool *pgxpool.Pool, mode string) error {
+	if mode == "off" {
+		return nil
+	}
+
+	m, err := newMigrator(pool)
+	if err != nil {
+		return err
+	}
+
+	if mode == "up" {
+		done, err := m.Up(ctx)
+		for _, mig := range done {
+			log.Printf("migrate: applied %s_%s", mig.Version, mig.Name)
+		}
+		return err
+	}
+
+	pending, err := m.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		log.Printf("migrate: %d pending migration(s), latest %s_%s (run `server migrate up` or set MIGRATIONS=up)",
+			len(pending), pending[len(pending)-1].Version, pending[len(pending)-1].Name)
+	}
+	return nil
+}
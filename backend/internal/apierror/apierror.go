@@ -0,0 +1,148 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Code はアプリが分岐に使うエラーコード（一度公開したら変えない）
+type Code string
+
+// FieldError はどの入力項目がなぜだめだったか
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+	Param  string `json:"param,omitempty"` // 上限・下限の文字数など
+}
+
+// FieldError.Reason に入る値
+const (
+	ReasonRequired      = "required"
+	ReasonInvalid       = "invalid"
+	ReasonTooLong       = "too_long"
+	ReasonTooShort      = "too_short"
+	ReasonInappropriate = "inappropriate"
+)
+
+// Error は API が返すエラー
+// メッセージは Code と Args から Accept-Language に合わせて組み立てる
+type Error struct {
+	Code    Code
+	Args    []any // メッセージの %s に入る値（項目名など）
+	Details any   // コードごとの付加情報（レスポンスの details）
+}
+
+func (e *Error) Error() string {
+	return e.message(English)
+}
+
+// Status は Code に対応する HTTP ステータス
+func (e *Error) Status() int {
+	if m, ok := catalog[e.Code]; ok {
+		return m.status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDetails は details を付けたコピーを返す
+func (e *Error) WithDetails(details any) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+func (e *Error) message(lang Lang) string {
+	m, ok := catalog[e.Code]
+	if !ok {
+		m = catalog[CodeInternal]
+	}
+	format := m.ja
+	if lang == English {
+		format = m.en
+	}
+	if len(e.Args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, e.Args...)
+}
+
+// New は Code のエラーを作る
+func New(code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Required は必須項目が空のときのエラー
+func Required(fields ...string) *Error {
+	return fieldError(CodeMissingParameter, ReasonRequired, fields)
+}
+
+// Invalid は値の形式や範囲が正しくないときのエラー
+func Invalid(fields ...string) *Error {
+	return fieldError(CodeInvalidParameter, ReasonInvalid, fields)
+}
+
+// TooLong は文字数の上限を超えたときのエラー
+func TooLong(fields ...string) *Error {
+	return fieldError(CodeTooLong, ReasonTooLong, fields)
+}
+
+// Validation は複数の項目をまとめて返す入力チェックのエラー
+func Validation(fields []FieldError) *Error {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Field
+	}
+	return &Error{
+		Code:    CodeValidationFailed,
+		Args:    []any{strings.Join(names, ", ")},
+		Details: fields,
+	}
+}
+
+func fieldError(code Code, reason string, fields []string) *Error {
+	details := make([]FieldError, len(fields))
+	for i, f := range fields {
+		details[i] = FieldError{Field: f, Reason: reason}
+	}
+	return &Error{
+		Code:    code,
+		Args:    []any{strings.Join(fields, ", ")},
+		Details: details,
+	}
+}
+
+// Response はエラーレスポンスの形
+// error は従来どおり文字列（人が読むメッセージ）で、アプリは code で分岐する
+type Response struct {
+	Error     string `json:"error"`
+	Code      Code   `json:"code"`
+	RequestID string `json:"request_id"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// Abort はエラーレスポンスを返して後続のハンドラを止める
+// *Error 以外のエラーは内部エラーとしてログに残し、中身はクライアントに見せない
+func Abort(c *gin.Context, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		log.Printf("request_id=%s %s %s: %v", RequestIDFrom(c), c.Request.Method, c.Request.URL.Path, err)
+		e = New(CodeInternal)
+	}
+
+	c.AbortWithStatusJSON(e.Status(), Response{
+		Error:     e.message(Language(c)),
+		Code:      e.Code,
+		RequestID: RequestIDFrom(c),
+		Details:   e.Details,
+	})
+}
+
+// NoRoute は存在しないパスへのリクエストに返すハンドラ
+func NoRoute(c *gin.Context) {
+	Abort(c, New(CodeRouteNotFound))
+}
@@ -0,0 +1,117 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(t *testing.T, err error, header http.Header) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/", func(c *gin.Context) { Abort(c, err) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return w, resp
+}
+
+func TestCatalogIsComplete(t *testing.T) {
+	for code, m := range catalog {
+		if m.status < 400 || m.ja == "" || m.en == "" {
+			t.Errorf("incomplete catalog entry for %s: %+v", code, m)
+		}
+	}
+}
+
+func TestAbortLocalizesMessage(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", "user_id を入力してください"},
+		{"ja-JP,ja;q=0.9", "user_id を入力してください"},
+		{"en-US,en;q=0.9", "user_id is required"},
+		{"fr-FR,en;q=0.5", "user_id is required"},
+		{"de-DE", "user_id を入力してください"},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.acceptLanguage != "" {
+			header.Set("Accept-Language", tt.acceptLanguage)
+		}
+
+		w, resp := serve(t, Required("user_id"), header)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+		if resp.Error != tt.want {
+			t.Errorf("Accept-Language %q: got %q, want %q", tt.acceptLanguage, resp.Error, tt.want)
+		}
+		if resp.Code != CodeMissingParameter {
+			t.Errorf("unexpected code: %s", resp.Code)
+		}
+	}
+}
+
+func TestAbortHidesInternalErrors(t *testing.T) {
+	w, resp := serve(t, errors.New(`ERROR: relation "public.works" does not exist`), nil)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+	if resp.Code != CodeInternal {
+		t.Fatalf("unexpected code: %s", resp.Code)
+	}
+	if resp.Error != catalog[CodeInternal].ja {
+		t.Fatalf("internal error leaked: %q", resp.Error)
+	}
+}
+
+func TestAbortRequestID(t *testing.T) {
+	w, resp := serve(t, New(CodeUserNotFound), nil)
+	if resp.RequestID == "" || w.Header().Get(RequestIDHeader) != resp.RequestID {
+		t.Fatalf("request id mismatch: header=%q body=%q", w.Header().Get(RequestIDHeader), resp.RequestID)
+	}
+
+	header := http.Header{}
+	header.Set(RequestIDHeader, "client-req-123")
+	_, resp = serve(t, New(CodeUserNotFound), header)
+	if resp.RequestID != "client-req-123" {
+		t.Fatalf("expected client request id, got %q", resp.RequestID)
+	}
+
+	header.Set(RequestIDHeader, "bad id\nwith newline")
+	_, resp = serve(t, New(CodeUserNotFound), header)
+	if resp.RequestID == "bad id\nwith newline" {
+		t.Fatal("unsafe request id should be replaced")
+	}
+}
+
+func TestFieldErrorDetails(t *testing.T) {
+	e := Required("user_id", "token")
+	details, ok := e.Details.([]FieldError)
+	if !ok || len(details) != 2 || details[1] != (FieldError{Field: "token", Reason: ReasonRequired}) {
+		t.Fatalf("unexpected details: %+v", e.Details)
+	}
+	if e.Error() != "user_id, token is required" {
+		t.Fatalf("unexpected message: %q", e.Error())
+	}
+}
@@ -0,0 +1,111 @@
+package apierror
+
+import "net/http"
+
+// 入力の誤り
+const (
+	CodeInvalidRequest    Code = "invalid_request"
+	CodeValidationFailed  Code = "validation_failed"
+	CodeMissingParameter  Code = "missing_parameter"
+	CodeInvalidParameter  Code = "invalid_parameter"
+	CodeTooLong           Code = "too_long"
+	CodeNothingToUpdate   Code = "nothing_to_update"
+	CodeCannotTargetSelf  Code = "cannot_target_self"
+	CodeInvalidSections   Code = "invalid_sections"
+	CodeLowQualityReview  Code = "low_quality_review"
+	CodeInappropriateText Code = "inappropriate_text"
+	CodeInvalidHandle     Code = "invalid_handle"
+	CodeReservedHandle    Code = "reserved_handle"
+)
+
+// 認証・権限
+const (
+	CodeInvalidCredentials   Code = "invalid_credentials"
+	CodeWrongPassword        Code = "wrong_password"
+	CodeAdminOnly            Code = "admin_only"
+	CodeNotMatchMember       Code = "not_match_member"
+	CodeNotYourReview        Code = "not_your_review"
+	CodeReviewLocked         Code = "review_locked"
+	CodeReviewEditExpired    Code = "review_edit_expired"
+	CodeReviewAlreadyVisible Code = "review_already_visible"
+	CodeMessagesLocked       Code = "messages_locked"
+	CodeUserBlocked          Code = "user_blocked"
+)
+
+// 見つからない
+const (
+	CodeRouteNotFound  Code = "route_not_found"
+	CodeUserNotFound   Code = "user_not_found"
+	CodeMatchNotFound  Code = "match_not_found"
+	CodeReviewNotFound Code = "review_not_found"
+	CodeReportNotFound Code = "report_not_found"
+	CodeTargetNotFound Code = "target_not_found"
+	CodeHandleNotFound Code = "handle_not_found"
+)
+
+// 状態の衝突
+const (
+	CodeEmailTaken          Code = "email_taken"
+	CodeHandleTaken         Code = "handle_taken"
+	CodeAlreadyReviewed     Code = "already_reviewed"
+	CodeAlreadyReported     Code = "already_reported"
+	CodeReportFinalized     Code = "report_finalized"
+	CodeAccountNotScheduled Code = "account_not_scheduled"
+	CodeHandleChangeTooSoon Code = "handle_change_too_soon"
+)
+
+// サーバー側の問題
+const (
+	CodeInternal Code = "internal_error"
+)
+
+type entry struct {
+	status int
+	ja     string
+	en     string
+}
+
+// catalog は Code ごとの HTTP ステータスとメッセージ（%s には Error.Args が入る）
+var catalog = map[Code]entry{
+	CodeInvalidRequest:    {http.StatusBadRequest, "リクエストの形式が正しくありません", "invalid request"},
+	CodeValidationFailed:  {http.StatusBadRequest, "%s の入力内容を確認してください", "invalid fields: %s"},
+	CodeMissingParameter:  {http.StatusBadRequest, "%s を入力してください", "%s is required"},
+	CodeInvalidParameter:  {http.StatusBadRequest, "%s の値が正しくありません", "invalid %s"},
+	CodeTooLong:           {http.StatusBadRequest, "%s が長すぎます", "%s is too long"},
+	CodeNothingToUpdate:   {http.StatusBadRequest, "変更する項目がありません", "nothing to update"},
+	CodeCannotTargetSelf:  {http.StatusBadRequest, "自分自身には実行できません", "cannot target yourself"},
+	CodeInvalidSections:   {http.StatusBadRequest, "レビューの観点の入力が正しくありません", "invalid sections"},
+	CodeLowQualityReview:  {http.StatusBadRequest, "レビューをもう少し具体的に書いてください", "low quality review"},
+	CodeInappropriateText: {http.StatusBadRequest, "不適切な表現が含まれています", "inappropriate text"},
+	CodeInvalidHandle:     {http.StatusBadRequest, "ハンドルは英小文字で始まる 3〜20 文字の英数字と _ で入力してください", "handle must be 3-20 characters of a-z, 0-9 and _ and start with a letter"},
+	CodeReservedHandle:    {http.StatusBadRequest, "このハンドルは使用できません", "handle is reserved"},
+
+	CodeInvalidCredentials:   {http.StatusUnauthorized, "メールアドレスまたはパスワードが正しくありません", "invalid email or password"},
+	CodeWrongPassword:        {http.StatusUnauthorized, "パスワードが正しくありません", "wrong password"},
+	CodeAdminOnly:            {http.StatusForbidden, "運営者のみ実行できます", "admin only"},
+	CodeNotMatchMember:       {http.StatusForbidden, "このマッチの参加者ではありません", "user not in this match"},
+	CodeNotYourReview:        {http.StatusForbidden, "このレビューは操作できません", "not your review"},
+	CodeReviewLocked:         {http.StatusForbidden, "相手にレビューを送ると読めるようになります", "review is locked"},
+	CodeReviewEditExpired:    {http.StatusForbidden, "編集できる期間を過ぎています", "edit window has passed"},
+	CodeReviewAlreadyVisible: {http.StatusForbidden, "相手が読めるようになったレビューは編集できません", "review already readable by recipient"},
+	CodeMessagesLocked:       {http.StatusForbidden, "お互いにレビューを送るとメッセージできます", "messages are available after both users have reviewed"},
+	CodeUserBlocked:          {http.StatusForbidden, "ブロック中のユーザーです", "user is blocked"},
+
+	CodeRouteNotFound:  {http.StatusNotFound, "ページが見つかりません", "not found"},
+	CodeUserNotFound:   {http.StatusNotFound, "ユーザーが見つかりません", "user not found"},
+	CodeMatchNotFound:  {http.StatusNotFound, "マッチが見つかりません", "match not found"},
+	CodeReviewNotFound: {http.StatusNotFound, "レビューが見つかりません", "review not found"},
+	CodeReportNotFound: {http.StatusNotFound, "通報が見つかりません", "report not found"},
+	CodeTargetNotFound: {http.StatusNotFound, "対象が見つかりません", "target not found"},
+	CodeHandleNotFound: {http.StatusNotFound, "ハンドルが見つかりません", "handle not found"},
+
+	CodeEmailTaken:          {http.StatusConflict, "このメールアドレスはすでに登録されています", "email already registered"},
+	CodeHandleTaken:         {http.StatusConflict, "このハンドルはすでに使われています", "handle already taken"},
+	CodeAlreadyReviewed:     {http.StatusConflict, "このマッチにはすでにレビューを送っています", "already reviewed"},
+	CodeAlreadyReported:     {http.StatusConflict, "すでに通報済みです", "already reported"},
+	CodeReportFinalized:     {http.StatusConflict, "この通報は対応済みです", "report is not open"},
+	CodeAccountNotScheduled: {http.StatusConflict, "退会手続き中のアカウントではありません", "account is not scheduled for deletion"},
+	CodeHandleChangeTooSoon: {http.StatusTooManyRequests, "ハンドルは最近変更されたため、まだ変更できません", "handle was changed recently"},
+
+	CodeInternal: {http.StatusInternalServerError, "サーバーでエラーが発生しました。時間をおいて再度お試しください", "internal server error"},
+}
@@ -0,0 +1,79 @@
+package apierror
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// RequestIDHeader はリクエスト ID をやりとりするヘッダー
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "request_id"
+
+// クライアントやプロキシが付けた ID はログを汚さない形のときだけ引き継ぐ
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{8,64}$`)
+
+// RequestID はリクエストごとに ID を割り当て、レスポンスヘッダーにも付けるミドルウェア
+// 問い合わせ時にエラーレスポンスの request_id からサーバーログをたどれるようにする
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFrom はリクエスト ID を返す（ミドルウェアを通っていなければここで割り当てる）
+func RequestIDFrom(c *gin.Context) string {
+	if id := c.GetString(requestIDKey); id != "" {
+		return id
+	}
+	id := newRequestID()
+	c.Set(requestIDKey, id)
+	c.Header(RequestIDHeader, id)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Lang はエラーメッセージの言語
+type Lang int
+
+const (
+	Japanese Lang = iota // アプリの既定
+	English
+)
+
+var matcher = language.NewMatcher([]language.Tag{language.Japanese, language.English})
+
+// Language は Accept-Language からメッセージの言語を選ぶ（なければ日本語）
+func Language(c *gin.Context) Lang {
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return Japanese
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return Japanese
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Japanese
+	}
+	if index == 1 {
+		return English
+	}
+	return Japanese
+}
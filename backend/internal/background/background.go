@@ -0,0 +1,77 @@
+// Package background はリクエストの外で動かす処理（マッチ判定・通知・定期処理など）を管理する
+// 終了時に実行中の処理を期限付きで待ち、途中で打ち切られないようにする
+package background
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Group は実行中のバックグラウンド処理をまとめて待てるようにする
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewGroup() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go は fn を別の goroutine で実行する
+// fn に渡す ctx は Shutdown の期限を過ぎたときにキャンセルされる
+// Shutdown を始めたあとは実行せずに false を返す
+func (g *Group) Go(name string, fn func(ctx context.Context)) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		log.Printf("background: %s dropped during shutdown", name)
+		return false
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		// 1 つの処理の panic でサーバー全体を落とさない
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("background: %s panicked: %v", name, r)
+			}
+		}()
+		fn(g.ctx)
+	}()
+	return true
+}
+
+// Wait は実行中の処理がすべて終わるまで待つ（テスト用）
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
+
+// Shutdown は新しい処理の受け付けをやめ、実行中の処理が終わるのを ctx の期限まで待つ
+// 期限を過ぎたら残っている処理の ctx をキャンセルして ctx.Err() を返す
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	defer g.cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
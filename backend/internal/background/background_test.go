@@ -0,0 +1,59 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForRunningTasks(t *testing.T) {
+	g := NewGroup()
+
+	finished := make(chan struct{})
+	g.Go("slow", func(ctx context.Context) {
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+
+	if err := g.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Fatal("shutdown returned before the task finished")
+	}
+
+	if g.Go("late", func(context.Context) {}) {
+		t.Fatal("tasks should be rejected after shutdown")
+	}
+}
+
+func TestShutdownCancelsTasksAfterDeadline(t *testing.T) {
+	g := NewGroup()
+
+	canceled := make(chan struct{})
+	g.Go("stuck", func(ctx context.Context) {
+		<-ctx.Done()
+		close(canceled)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := g.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("task context was not canceled")
+	}
+}
+
+func TestPanicDoesNotEscape(t *testing.T) {
+	g := NewGroup()
+	g.Go("panics", func(context.Context) { panic("boom") })
+	g.Wait()
+}
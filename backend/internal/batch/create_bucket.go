@@ -7,14 +7,15 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"strings"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 // CreateBucketIfNotExists は指定したバケットが存在しない場合に作成する
-func CreateBucketIfNotExists(bucketName string, isPublic bool) error {
-	supabaseURL := os.Getenv("SUPABASE_URL")
-	serviceKey := os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
+func CreateBucketIfNotExists(store storage.Config, bucketName string, isPublic bool) error {
+	supabaseURL := store.URL
+	serviceKey := store.ServiceRoleKey
 
 	// バケット作成のリクエストボディ
 	body := map[string]interface{}{
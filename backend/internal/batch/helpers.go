@@ -4,29 +4,29 @@ import (
 	"context"
 	"log"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func getUserIDByEmail(ctx context.Context, email string) string {
+func getUserIDByEmail(ctx context.Context, pool *pgxpool.Pool, email string) string {
 	var id string
-	_ = db.Pool.QueryRow(ctx,
+	_ = pool.QueryRow(ctx,
 		`SELECT id FROM users WHERE email=$1`,
 		email,
 	).Scan(&id)
 	return id
 }
 
-func getOneWorkID(ctx context.Context, userID string) string {
+func getOneWorkID(ctx context.Context, pool *pgxpool.Pool, userID string) string {
 	var id string
-	_ = db.Pool.QueryRow(ctx,
+	_ = pool.QueryRow(ctx,
 		`SELECT id FROM works WHERE user_id=$1 LIMIT 1`,
 		userID,
 	).Scan(&id)
 	return id
 }
 
-func insertLike(ctx context.Context, fromUserID, toWorkID string) {
-	_, _ = db.Pool.Exec(ctx, `
+func insertLike(ctx context.Context, pool *pgxpool.Pool, fromUserID, toWorkID string) {
+	_, _ = pool.Exec(ctx, `
 		INSERT INTO swipes (from_user_id, to_work_id, to_work_user_id, is_like)
 		SELECT $1, w.id, w.user_id, true
 		FROM works w
@@ -38,13 +38,14 @@ func insertLike(ctx context.Context, fromUserID, toWorkID string) {
 
 func insertReview(
 	ctx context.Context,
+	pool *pgxpool.Pool,
 	matchID,
 	fromUserID,
 	toUserID,
 	workID,
 	comment string,
 ) {
-	_, err := db.Pool.Exec(ctx, `
+	_, err := pool.Exec(ctx, `
 		INSERT INTO public.reviews (
 			match_id,
 			from_user_id,
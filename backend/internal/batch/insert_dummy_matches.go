@@ -4,11 +4,16 @@ import (
 	"context"
 	"log"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 	"github.com/p2hacks2025/pre-12/backend/internal/service"
 )
 
-func InsertDummySwipesAndMatches() {
+func InsertDummySwipesAndMatches(pool *pgxpool.Pool) {
 	ctx := context.Background()
+	repos := repository.NewPG(pool)
+	matcher := service.NewMatcher(repos, service.NewNotifier(repos, realtime.NewHub()))
 
 	// マッチさせたいユーザーペア
 	pairs := [][]string{
@@ -314,16 +319,16 @@ func InsertDummySwipesAndMatches() {
 	}
 
 	for _, p := range pairs {
-		u1 := getUserIDByEmail(ctx, p[0])
-		u2 := getUserIDByEmail(ctx, p[1])
+		u1 := getUserIDByEmail(ctx, pool, p[0])
+		u2 := getUserIDByEmail(ctx, pool, p[1])
 
 		if u1 == "" || u2 == "" {
 			log.Println("user not found:", p)
 			continue
 		}
 
-		w1 := getOneWorkID(ctx, u1)
-		w2 := getOneWorkID(ctx, u2)
+		w1 := getOneWorkID(ctx, pool, u1)
+		w2 := getOneWorkID(ctx, pool, u2)
 
 		if w1 == "" || w2 == "" {
 			log.Println("work not found:", p)
@@ -331,12 +336,15 @@ func InsertDummySwipesAndMatches() {
 		}
 
 		// u1 -> u2 like
-		insertLike(ctx, u1, w2)
-		service.CheckAndCreateMatch(u1, w2)
+		insertLike(ctx, pool, u1, w2)
+		matcher.CheckAndCreateMatch(ctx, u1, w2)
 
 		// u2 -> u1 like（ここでマッチ成立）
-		insertLike(ctx, u2, w1)
-		service.CheckAndCreateMatch(u2, w1)
+		insertLike(ctx, pool, u2, w1)
+		if _, err := matcher.CheckAndCreateMatch(ctx, u2, w1); err != nil {
+			log.Println("match failed:", p, err)
+			continue
+		}
 
 		log.Printf("dummy match created: %s <-> %s\n", p[0], p[1])
 	}
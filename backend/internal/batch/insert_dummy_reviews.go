@@ -5,13 +5,13 @@ import (
 	"log"
 	"math/rand"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func InsertDummyReviews() {
+func InsertDummyReviews(pool *pgxpool.Pool) {
 	ctx := context.Background()
 
-	rows, err := db.Pool.Query(ctx, `
+	rows, err := pool.Query(ctx, `
 		SELECT
 			id,
 			user1_id,
@@ -102,7 +102,7 @@ func InsertDummyReviews() {
 		comment2 := comments[rand.Intn(len(comments))]
 
 		// user1 -> user2
-		insertReview(ctx,
+		insertReview(ctx, pool,
 			matchID,
 			user1ID,
 			user2ID,
@@ -111,7 +111,7 @@ func InsertDummyReviews() {
 		)
 
 		// user2 -> user1
-		insertReview(ctx,
+		insertReview(ctx, pool,
 			matchID,
 			user2ID,
 			user1ID,
@@ -5,15 +5,15 @@ import (
 	"log"
 	"math/rand"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // InsertDummyReviewsSkipEven は matches からデータを取得し、
 // 偶数番目の match では user2 -> user1 をスキップしてダミーレビューを挿入する
-func InsertDummyReviewsSkipEven() {
+func InsertDummyReviewsSkipEven(pool *pgxpool.Pool) {
 	ctx := context.Background()
 
-	rows, err := db.Pool.Query(ctx, `
+	rows, err := pool.Query(ctx, `
 		SELECT
 			id,
 			user1_id,
@@ -105,7 +105,7 @@ func InsertDummyReviewsSkipEven() {
 		comment2 := comments[rand.Intn(len(comments))]
 
 		// user1 -> user2（常に作る）
-		insertReview(ctx,
+		insertReview(ctx, pool,
 			matchID,
 			user1ID,
 			user2ID,
@@ -115,7 +115,7 @@ func InsertDummyReviewsSkipEven() {
 
 		// 偶数番目はスキップ
 		if i%2 == 1 {
-			insertReview(ctx,
+			insertReview(ctx, pool,
 				matchID,
 				user2ID,
 				user1ID,
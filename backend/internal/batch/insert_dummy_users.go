@@ -5,11 +5,11 @@ import (
 	"log"
 	"strings"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // InsertDummyUsers は users テーブルにダミーデータを挿入する関数
-func InsertDummyUsers() {
+func InsertDummyUsers(pool *pgxpool.Pool) {
 	users := []struct {
 		Username string
 		Email    string
@@ -123,7 +123,7 @@ func InsertDummyUsers() {
 	for _, u := range users {
 		// まずユーザーを挿入して id を取得
 		var userID string
-		err := db.Pool.QueryRow(
+		err := pool.QueryRow(
 			ctx,
 			`INSERT INTO public.users (username, email, password, bio)
 			 VALUES ($1, $2, $3, $4)
@@ -134,7 +134,7 @@ func InsertDummyUsers() {
 
 		// ON CONFLICT の場合はすでに存在する id を取得
 		if err != nil {
-			err = db.Pool.QueryRow(ctx, "SELECT id FROM public.users WHERE email = $1", u.Email).Scan(&userID)
+			err = pool.QueryRow(ctx, "SELECT id FROM public.users WHERE email = $1", u.Email).Scan(&userID)
 			if err != nil {
 				log.Printf("failed to get user id for %s: %v", u.Email, err)
 				continue
@@ -145,7 +145,7 @@ func InsertDummyUsers() {
 		iconPath := strings.Join([]string{"icons", userID, u.IconFile}, "/")
 
 		// icon_path を更新
-		_, err = db.Pool.Exec(ctx, "UPDATE public.users SET icon_path=$1 WHERE id=$2", iconPath, userID)
+		_, err = pool.Exec(ctx, "UPDATE public.users SET icon_path=$1 WHERE id=$2", iconPath, userID)
 		if err != nil {
 			log.Printf("failed to update icon_path for %s: %v", u.Email, err)
 			continue
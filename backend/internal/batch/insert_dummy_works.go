@@ -5,11 +5,11 @@ import (
 	"log"
 	"strings"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // InsertDummyWorks は works テーブルにダミーデータを挿入する関数
-func InsertDummyWorks() {
+func InsertDummyWorks(pool *pgxpool.Pool) {
 	works := []struct {
 		UserEmail   string
 		ImageFile   string // ファイル名だけ
@@ -122,7 +122,7 @@ func InsertDummyWorks() {
 	for _, w := range works {
 		// user_id を取得
 		var userID string
-		err := db.Pool.QueryRow(ctx, "SELECT id FROM public.users WHERE email = $1", w.UserEmail).Scan(&userID)
+		err := pool.QueryRow(ctx, "SELECT id FROM public.users WHERE email = $1", w.UserEmail).Scan(&userID)
 		if err != nil {
 			log.Printf("failed to get user id for %s: %v", w.UserEmail, err)
 			continue
@@ -132,7 +132,7 @@ func InsertDummyWorks() {
 		imagePath := strings.Join([]string{"works", userID, w.ImageFile}, "/")
 
 		// works テーブルに挿入
-		_, err = db.Pool.Exec(
+		_, err = pool.Exec(
 			ctx,
 			`INSERT INTO public.works (user_id, image_path, title, description)
 			 VALUES ($1, $2, $3, $4)
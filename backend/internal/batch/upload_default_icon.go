@@ -10,7 +10,7 @@ import (
 
 // UploadDefaultIcon はデフォルトアイコンを
 // Supabase Storage (icons/default.png) にアップロードする
-func UploadDefaultIcon() {
+func UploadDefaultIcon(store storage.Config) {
 	localPath := "assets/icons/default.png"
 
 	file, err := os.Open(localPath)
@@ -24,7 +24,7 @@ func UploadDefaultIcon() {
 	const storagePath = "default.png"
 
 	// PUT で上書き
-	if err := storage.UploadLocalFileToSupabase(context.Background(), file, bucket, storagePath); err != nil {
+	if err := store.UploadLocalFileToSupabase(context.Background(), file, bucket, storagePath); err != nil {
 		log.Fatal("failed to upload default icon:", err)
 	}
 
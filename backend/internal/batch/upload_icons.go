@@ -8,13 +8,13 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 // UploadIconsFromLocal はローカルの icons フォルダから
 // Supabase Storage にアップロードし、users.icon_url を更新する関数
-func UploadIconsFromLocal() {
+func UploadIconsFromLocal(pool *pgxpool.Pool, store storage.Config) {
 	files, err := os.ReadDir("assets/icons")
 	if err != nil {
 		log.Fatal("failed to read local icons folder:", err)
@@ -31,7 +31,7 @@ func UploadIconsFromLocal() {
 		email := base + "@example.com"
 
 		var userID string
-		err := db.Pool.QueryRow(context.Background(), "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
+		err := pool.QueryRow(context.Background(), "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
 		if err != nil {
 			log.Printf("failed to get user id for %s: %v", f.Name(), err)
 			continue
@@ -46,7 +46,7 @@ func UploadIconsFromLocal() {
 		}
 
 		// PUT で上書き
-		if err := storage.UploadLocalFileToSupabase(context.Background(), file, "icons", newPath); err != nil {
+		if err := store.UploadLocalFileToSupabase(context.Background(), file, "icons", newPath); err != nil {
 			log.Printf("failed to upload %s: %v", f.Name(), err)
 			file.Close()
 			continue
@@ -8,13 +8,13 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 // UploadWorksFromLocal はローカルの画像フォルダから
 // Supabase Storage にアップロードし、DBに登録する関数
-func UploadWorksFromLocal() {
+func UploadWorksFromLocal(pool *pgxpool.Pool, store storage.Config) {
 	files, err := os.ReadDir("assets/works")
 	if err != nil {
 		log.Fatal("failed to read local works folder:", err)
@@ -31,7 +31,7 @@ func UploadWorksFromLocal() {
 		email := base + "@example.com"
 
 		var userID string
-		err := db.Pool.QueryRow(context.Background(), "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
+		err := pool.QueryRow(context.Background(), "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
 		if err != nil {
 			log.Printf("failed to get user id for %s: %v", f.Name(), err)
 			continue
@@ -46,7 +46,7 @@ func UploadWorksFromLocal() {
 		}
 
 		// PUT で上書き
-		if err := storage.UploadLocalFileToSupabase(context.Background(), file, "works", newPath); err != nil {
+		if err := store.UploadLocalFileToSupabase(context.Background(), file, "works", newPath); err != nil {
 			log.Printf("failed to upload %s: %v", f.Name(), err)
 			file.Close()
 			continue
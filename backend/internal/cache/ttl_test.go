@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLExpires(t *testing.T) {
+	now := time.Now()
+	c := NewTTL[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected cached value, got %d %v", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected value to expire")
+	}
+}
+
+func TestTTLDelete(t *testing.T) {
+	c := NewTTL[string, int](time.Minute)
+	c.Set("a", 1)
+	c.Delete("a")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected value to be deleted")
+	}
+}
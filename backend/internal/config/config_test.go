@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+)
+
+func lookupMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestFromEnvDefaults(t *testing.T) {
+	cfg, err := FromEnv(ProfileLocal, lookupMap(map[string]string{
+		"DATABASE_URL": "postgres://localhost/app",
+		"SUPABASE_URL": "https://example.supabase.co/",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Addr() != ":8080" || cfg.ShutdownTimeout != 20*time.Second || cfg.Migrations != "check" || cfg.Realtime.Backend != "memory" || cfg.Quality != quality.ModeReject {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.Supabase.URL != "https://example.supabase.co" {
+		t.Fatalf("trailing slash should be trimmed, got %s", cfg.Supabase.URL)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	cfg, err := FromEnv(ProfileProd, lookupMap(map[string]string{
+		"PORT":                "70000",
+		"PUSH_PROVIDER":       "http",
+		"TEXT_FILTER_MODE":    "drop",
+		"REALTIME_BACKEND":    "redis",
+		"SUPABASE_URL":        "example.supabase.co",
+		"MIGRATIONS":          "auto",
+		"PUSH_ENDPOINT":       "https://push.example.com",
+		"REVIEW_QUALITY_MODE": "flag",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{
+		"PORT", "DATABASE_URL", "MIGRATIONS", "SUPABASE_URL must be an absolute URL",
+		"SUPABASE_SERVICE_ROLE_KEY is required in prod", "REALTIME_BACKEND",
+		"PUSH_SERVER_KEY", "TEXT_FILTER_MODE",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %s:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "REVIEW_QUALITY_MODE") || strings.Contains(err.Error(), "PUSH_ENDPOINT") {
+		t.Errorf("valid settings should not be reported:\n%v", err)
+	}
+}
+
+func TestFromEnvInvalidPort(t *testing.T) {
+	if _, err := FromEnv(ProfileLocal, lookupMap(map[string]string{"PORT": "http"})); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestTestProfileDoesNotRequireDatabase(t *testing.T) {
+	cfg, _ := FromEnv(ProfileTest, lookupMap(nil))
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	write := func(path, body string) {
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(envFile, "DATABASE_URL=postgres://from-env-file/app\nPORT=9000\nREVIEW_QUALITY_MODE=flag\n")
+	write(envFile+".prod", "DATABASE_URL=postgres://from-prod-file/app\nSUPABASE_URL=https://prod.supabase.co\nSUPABASE_SERVICE_ROLE_KEY=key\n")
+
+	// godotenv.Load は環境変数に書き込むので、テストの後で元に戻るようにしておく
+	for _, key := range []string{"DATABASE_URL", "PORT", "REVIEW_QUALITY_MODE", "SUPABASE_URL", "SUPABASE_SERVICE_ROLE_KEY", "APP_ENV"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("REVIEW_QUALITY_MODE", "reject")
+
+	cfg, args, err := Load([]string{"-profile", "prod", "-env-file", envFile, "-port", "9100", "migrate", "status"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://from-prod-file/app" {
+		t.Errorf(".env.prod should win over .env, got %s", cfg.DatabaseURL)
+	}
+	if cfg.Quality != quality.ModeReject {
+		t.Errorf("environment should win over .env, got %s", cfg.Quality)
+	}
+	if cfg.Port != 9100 {
+		t.Errorf("flag should win over PORT, got %d", cfg.Port)
+	}
+	if strings.Join(args, " ") != "migrate status" {
+		t.Errorf("unexpected remaining args: %v", args)
+	}
+}
@@ -2,23 +2,25 @@ package db
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
+	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var Pool *pgxpool.Pool
-
-func Init() {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL is not set")
+// Connect は url の Postgres に接続し、疎通を確認したプールを返す
+func Connect(ctx context.Context, url string) (*pgxpool.Pool, error) {
+	if url == "" {
+		return nil, errors.New("DATABASE_URL is not set")
 	}
 
-	var err error
-	Pool, err = pgxpool.New(context.Background(), dbURL)
+	pool, err := pgxpool.New(ctx, url)
 	if err != nil {
-		log.Fatalf("unable to connect to database: %v", err)
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
+	return pool, nil
 }
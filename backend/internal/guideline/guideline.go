@@ -0,0 +1,181 @@
+package guideline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// レビューの観点（README の「ガイドライン（観点）」）
+const (
+	AspectFirstImpression = "first_impression"
+	AspectObservation     = "observation"
+	AspectInterpretation  = "interpretation"
+	AspectImprovement     = "improvement"
+)
+
+// 観点ごとの評価（任意）の範囲
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Aspect はレビュー画面に提示する観点の定義
+type Aspect struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Prompts     []string `json:"prompts"`
+	Required    bool     `json:"required"`
+}
+
+// Aspects は表示順に並べた観点の一覧
+var Aspects = []Aspect{
+	{
+		Key:         AspectFirstImpression,
+		Label:       "初期印象",
+		Description: "作品を見た瞬間に感じたこと",
+		Prompts:     []string{"最初に目に入ったのはどこ？", "どんな気持ちになった？"},
+		Required:    true,
+	},
+	{
+		Key:         AspectObservation,
+		Label:       "観察",
+		Description: "色・構図・質感など、具体的に効いている要素",
+		Prompts:     []string{"どの要素が効いている？", "色や構図で気になったところは？"},
+		Required:    true,
+	},
+	{
+		Key:         AspectInterpretation,
+		Label:       "解釈",
+		Description: "作品から受け取った意味や物語",
+		Prompts:     []string{"どこで感情が動いた？", "作者は何を伝えたかったと思う？"},
+	},
+	{
+		Key:         AspectImprovement,
+		Label:       "改善/伸びしろ",
+		Description: "次の制作につながる提案",
+		Prompts:     []string{"もっと良くなりそうなところは？", "次に見てみたい表現は？"},
+	},
+}
+
+// Find は key の観点を返す
+func Find(key string) (Aspect, bool) {
+	for _, a := range Aspects {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return Aspect{}, false
+}
+
+// MaxBodyLength は観点 1 つあたりの本文の最大文字数
+const MaxBodyLength = 1000
+
+// Section は観点ごとのレビュー本文と評価
+type Section struct {
+	Aspect string `json:"aspect"`
+	Body   string `json:"body" binding:"max=1000"` // MaxBodyLength
+	Rating *int   `json:"rating,omitempty"`
+}
+
+// SectionError は観点ごとの入力エラー
+type SectionError struct {
+	Aspect  string `json:"aspect"`
+	Message string `json:"message"`
+}
+
+// ValidationError は Validate が返すエラー（観点ごとのエラーをまとめたもの）
+type ValidationError struct {
+	Errors []SectionError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, se := range e.Errors {
+		msgs = append(msgs, se.Aspect+": "+se.Message)
+	}
+	return "invalid sections: " + strings.Join(msgs, ", ")
+}
+
+// Normalize は本文の前後の空白を取り除き、観点の表示順に並べ替える
+func Normalize(sections []Section) []Section {
+	out := make([]Section, 0, len(sections))
+	for _, a := range Aspects {
+		for _, s := range sections {
+			if s.Aspect == a.Key {
+				s.Body = strings.TrimSpace(s.Body)
+				out = append(out, s)
+			}
+		}
+	}
+	// 未知の観点は Validate で弾くため、ここでは末尾に残しておく
+	for _, s := range sections {
+		if _, ok := Find(s.Aspect); !ok {
+			s.Body = strings.TrimSpace(s.Body)
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Validate は必須観点の有無・未知の観点・重複・評価の範囲を検証する
+func Validate(sections []Section) error {
+	var errs []SectionError
+	seen := map[string]bool{}
+
+	for _, s := range sections {
+		if _, ok := Find(s.Aspect); !ok {
+			errs = append(errs, SectionError{Aspect: s.Aspect, Message: "unknown aspect"})
+			continue
+		}
+		if seen[s.Aspect] {
+			errs = append(errs, SectionError{Aspect: s.Aspect, Message: "duplicated aspect"})
+			continue
+		}
+		seen[s.Aspect] = true
+
+		if s.Rating != nil && (*s.Rating < MinRating || *s.Rating > MaxRating) {
+			errs = append(errs, SectionError{
+				Aspect:  s.Aspect,
+				Message: fmt.Sprintf("rating must be between %d and %d", MinRating, MaxRating),
+			})
+		}
+	}
+
+	for _, a := range Aspects {
+		if !a.Required {
+			continue
+		}
+		filled := false
+		for _, s := range sections {
+			if s.Aspect == a.Key && strings.TrimSpace(s.Body) != "" {
+				filled = true
+			}
+		}
+		if !filled {
+			errs = append(errs, SectionError{Aspect: a.Key, Message: "required"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// Compose は観点ごとの本文を 1 つのコメントにまとめる
+// comment しか表示できない旧クライアント向け
+func Compose(sections []Section) string {
+	parts := make([]string, 0, len(sections))
+	for _, s := range sections {
+		if s.Body == "" {
+			continue
+		}
+		label := s.Aspect
+		if a, ok := Find(s.Aspect); ok {
+			label = a.Label
+		}
+		parts = append(parts, "【"+label+"】"+s.Body)
+	}
+	return strings.Join(parts, "\n\n")
+}
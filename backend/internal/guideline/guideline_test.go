@@ -0,0 +1,77 @@
+package guideline
+
+import (
+	"errors"
+	"testing"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestValidateAcceptsRequiredSections(t *testing.T) {
+	sections := []Section{
+		{Aspect: AspectObservation, Body: "光の入り方がきれい", Rating: intPtr(5)},
+		{Aspect: AspectFirstImpression, Body: "透明感がある"},
+	}
+
+	if err := Validate(sections); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateReportsEachProblem(t *testing.T) {
+	sections := []Section{
+		{Aspect: AspectFirstImpression, Body: "   "},
+		{Aspect: AspectInterpretation, Body: "物語を感じる", Rating: intPtr(9)},
+		{Aspect: AspectInterpretation, Body: "重複"},
+		{Aspect: "color", Body: "未知の観点"},
+	}
+
+	err := Validate(sections)
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	got := map[string]string{}
+	for _, e := range ve.Errors {
+		got[e.Aspect+"/"+e.Message] = e.Message
+	}
+
+	for _, want := range []string{
+		AspectFirstImpression + "/required",
+		AspectObservation + "/required",
+		AspectInterpretation + "/duplicated aspect",
+		"color/unknown aspect",
+	} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("missing error %q in %+v", want, ve.Errors)
+		}
+	}
+
+	foundRating := false
+	for _, e := range ve.Errors {
+		if e.Aspect == AspectInterpretation && e.Message != "duplicated aspect" {
+			foundRating = true
+		}
+	}
+	if !foundRating {
+		t.Errorf("expected rating error for %s", AspectInterpretation)
+	}
+}
+
+func TestNormalizeAndCompose(t *testing.T) {
+	sections := Normalize([]Section{
+		{Aspect: AspectImprovement, Body: " 背景も見たい "},
+		{Aspect: AspectFirstImpression, Body: "透明感がある"},
+	})
+
+	if sections[0].Aspect != AspectFirstImpression || sections[1].Body != "背景も見たい" {
+		t.Fatalf("unexpected normalized sections: %+v", sections)
+	}
+
+	want := "【初期印象】透明感がある\n\n【改善/伸びしろ】背景も見たい"
+	if got := Compose(sections); got != want {
+		t.Fatalf("unexpected composed comment:\n%s", got)
+	}
+}
@@ -0,0 +1,49 @@
+package handle
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ハンドル（@handle）の長さ
+const (
+	MinLength = 3
+	MaxLength = 20
+)
+
+var (
+	ErrInvalid  = errors.New("handle must be 3-20 characters of a-z, 0-9 and _ and start with a letter")
+	ErrReserved = errors.New("handle is reserved")
+)
+
+// URL にそのまま使える文字だけを許す
+var pattern = regexp.MustCompile(`^[a-z][a-z0-9_]{2,19}$`)
+
+// reserved はルーティングや運営と紛らわしいので使えないハンドル
+var reserved = map[string]bool{
+	"about": true, "admin": true, "administrator": true, "api": true, "app": true,
+	"events": true, "help": true, "home": true, "kiratto": true, "login": true,
+	"logout": true, "matches": true, "moderator": true, "notifications": true,
+	"null": true, "official": true, "privacy": true, "reports": true, "reviews": true,
+	"root": true, "settings": true, "signup": true, "staff": true, "support": true,
+	"system": true, "terms": true, "undefined": true, "users": true, "works": true,
+}
+
+// Normalize は前後の空白と先頭の @ を取り除き、小文字にする
+func Normalize(h string) string {
+	h = strings.TrimSpace(h)
+	h = strings.TrimPrefix(h, "@")
+	return strings.ToLower(h)
+}
+
+// Validate は正規化済みのハンドルを検証する
+func Validate(h string) error {
+	if !pattern.MatchString(h) {
+		return ErrInvalid
+	}
+	if reserved[h] || strings.HasPrefix(h, "kiratto") {
+		return ErrReserved
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package handle
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("  @Kira_Art "); got != "kira_art" {
+		t.Fatalf("Normalize = %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, h := range []string{"abc", "kira_art", "a123456789012345678z"} {
+		if err := Validate(h); err != nil {
+			t.Errorf("Validate(%q) = %v", h, err)
+		}
+	}
+
+	for _, h := range []string{"ab", "1abc", "kira-art", "きらっと", "a1234567890123456789z", "kira art"} {
+		if err := Validate(h); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Validate(%q) = %v, want ErrInvalid", h, err)
+		}
+	}
+
+	for _, h := range []string{"admin", "settings", "kiratto_official"} {
+		if err := Validate(h); !errors.Is(err, ErrReserved) {
+			t.Errorf("Validate(%q) = %v, want ErrReserved", h, err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+// AnonymousUsername は退会したユーザーのレビューに表示する名前
+const AnonymousUsername = "退会したユーザー"
+
+type AccountRequest struct {
+	UserID   string `json:"user_id" binding:"required,uuid"`
+	Password string `json:"password" binding:"required"`
+}
+
+// checkPassword は本人確認のためにパスワードを照合する
+func (s *Server) checkPassword(ctx context.Context, userID, password string) bool {
+	u, err := s.Users.Get(ctx, userID)
+	return err == nil && u.Password == password
+}
+
+// DeleteMyAccount は退会を申請する
+// すぐには消さず、AccountDeletionGrace のあとで完全に削除する（それまでは取り消せる）
+func (s *Server) DeleteMyAccount(c *gin.Context) {
+	var req AccountRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx := context.Background()
+
+	if !s.checkPassword(ctx, req.UserID, req.Password) {
+		apierror.Abort(c, apierror.New(apierror.CodeWrongPassword))
+		return
+	}
+
+	deletedAt, err := s.Accounts.ScheduleDeletion(ctx, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	// 退会後はプッシュ通知を送らない
+	if err := s.Devices.RemoveForUser(ctx, req.UserID); err != nil {
+		log.Printf("failed to remove device tokens of %s: %v", req.UserID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "account deletion scheduled",
+		"purge_at": deletedAt.Add(service.AccountDeletionGrace).Format(time.RFC3339),
+	})
+}
+
+// RestoreMyAccount は猶予期間中の退会申請を取り消す
+func (s *Server) RestoreMyAccount(c *gin.Context) {
+	var req AccountRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx := context.Background()
+
+	if !s.checkPassword(ctx, req.UserID, req.Password) {
+		apierror.Abort(c, apierror.New(apierror.CodeWrongPassword))
+		return
+	}
+
+	err := s.Accounts.CancelDeletion(ctx, req.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeAccountNotScheduled))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account restored"})
+}
+
+// exportFile は ZIP に入れる JSON ファイル
+type exportFile struct {
+	Name string
+	Data any
+}
+
+// exportFiles はエクスポートに含めるデータ（受け取ったレビューは読めるものだけ）
+func exportFiles(e repository.Export) []exportFile {
+	return []exportFile{
+		{"profile.json", e.Profile},
+		{"works.json", e.Works},
+		{"swipes.json", e.Swipes},
+		{"matches.json", e.Matches},
+		{"reviews_sent.json", e.ReviewsSent},
+		{"reviews_received.json", e.ReviewsReceived},
+	}
+}
+
+// ExportMyData は自分のデータ（プロフィール・作品と画像・スワイプ・マッチ・レビュー）を ZIP で返す
+// 個人データを丸ごと返すので、退会と同じくパスワードで本人確認する
+func (s *Server) ExportMyData(c *gin.Context) {
+	var req AccountRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	userID := req.UserID
+
+	ctx := c.Request.Context()
+
+	if !s.checkPassword(ctx, userID, req.Password) {
+		apierror.Abort(c, apierror.New(apierror.CodeWrongPassword))
+		return
+	}
+
+	// ZIP を書き始めるとステータスを変えられないので、DB のデータは先にすべて読む
+	export, err := s.Accounts.Export(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	files := exportFiles(export)
+	data := make([][]byte, len(files))
+	for i, f := range files {
+		if data[i], err = json.Marshal(f.Data); err != nil {
+			apierror.Abort(c, err)
+			return
+		}
+	}
+
+	var images []struct{ Name, Path string }
+	for _, w := range export.Works {
+		if w.ImagePath != nil {
+			images = append(images, struct{ Name, Path string }{"works/" + w.ID + filepath.Ext(*w.ImagePath), *w.ImagePath})
+		}
+	}
+	if icon := export.Profile.IconPath; icon != nil && *icon != DefaultIconPath {
+		images = append(images, struct{ Name, Path string }{"icon" + filepath.Ext(*icon), *icon})
+	}
+
+	filename := fmt.Sprintf("kiratto-export-%s.zip", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	zw := zip.NewWriter(c.Writer)
+	defer zw.Close()
+
+	for i, f := range files {
+		if err := writeZipFile(zw, f.Name, data[i]); err != nil {
+			log.Printf("export %s: %v", userID, err)
+			return
+		}
+	}
+
+	// 画像は取得できなかったものを manifest に記録して続ける
+	missing := []string{}
+	for _, img := range images {
+		data, err := s.Storage.DownloadObject(ctx, img.Path)
+		if err != nil {
+			log.Printf("export %s: failed to download %s: %v", userID, img.Path, err)
+			missing = append(missing, img.Path)
+			continue
+		}
+		if err := writeZipFile(zw, img.Name, data); err != nil {
+			log.Printf("export %s: %v", userID, err)
+			return
+		}
+	}
+
+	manifest, _ := json.MarshalIndent(gin.H{
+		"user_id":       userID,
+		"exported_at":   time.Now().UTC().Format(time.RFC3339),
+		"missing_files": missing,
+	}, "", "  ")
+	if err := writeZipFile(zw, "manifest.json", manifest); err != nil {
+		log.Printf("export %s: %v", userID, err)
+	}
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
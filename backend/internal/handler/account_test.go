@@ -0,0 +1,159 @@
+// backend/internal/handler/account_test.go
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+func TestExportMyData(t *testing.T) {
+	r := setupTestRouter(withAccount)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+	_ = createTestReview(t, matchID, userA, userB, workB, "光の入り方がきれい")
+	_ = createTestReview(t, matchID, userB, userA, workA, "色の重ね方が丁寧")
+
+	// 本人確認なしでは返さない
+	if w := postJSON(r, http.MethodPost, "/me/export", AccountRequest{UserID: userA, Password: "wrong"}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+
+	w := postJSON(r, http.MethodPost, "/me/export", AccountRequest{UserID: userA, Password: "dummy"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	for _, name := range []string{"profile.json", "works.json", "swipes.json", "matches.json", "reviews_sent.json", "reviews_received.json", "manifest.json"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("missing %s in export", name)
+		}
+	}
+
+	if bytes.Contains(files["profile.json"], []byte("password")) {
+		t.Fatal("export must not include password")
+	}
+
+	var received []map[string]any
+	if err := json.Unmarshal(files["reviews_received.json"], &received); err != nil {
+		t.Fatalf("invalid reviews_received.json: %v", err)
+	}
+	if len(received) != 1 || received[0]["comment"] != "色の重ね方が丁寧" {
+		t.Fatalf("unexpected received reviews: %v", received)
+	}
+}
+
+func TestDeleteMyAccount_AnonymizesReviews(t *testing.T) {
+	r := setupTestRouter(withAccount, withSwipe, withReview, withReceivedReviews)
+	ctx := context.Background()
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+
+	// 本番と同じ流れ（相互いいね → マッチ判定 → レビュー投稿）で作る
+	for _, like := range []SwipeRequest{
+		{FromUserID: userB, ToWorkID: workA, IsLike: true},
+		{FromUserID: userA, ToWorkID: workB, IsLike: true},
+	} {
+		if w := postJSON(r, http.MethodPost, "/swipe", like); w.Code != http.StatusOK {
+			t.Fatalf("like failed: %d %s", w.Code, w.Body.String())
+		}
+	}
+	testServer.Tasks.Wait()
+
+	matches, err := testServer.Matches.ListForUser(ctx, userA)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %+v (err %v)", matches, err)
+	}
+	matchID := matches[0].MatchID
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "光の入り方がきれいで、奥の影との対比が印象に残りました",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	testServer.Tasks.Wait()
+	reviewID, err := testServer.Reviews.FindID(ctx, matchID, userA)
+	if err != nil {
+		t.Fatalf("failed to find review: %v", err)
+	}
+
+	if w := postJSON(r, http.MethodDelete, "/me", AccountRequest{UserID: userA, Password: "wrong"}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if w := postJSON(r, http.MethodDelete, "/me", AccountRequest{UserID: userA, Password: "dummy"}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 削除ジョブの本体（猶予期間の判定は PurgeDeletedAccounts が行う）
+	if err := service.PurgeAccount(ctx, testServer.Accounts, testServer.Storage, userA); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+
+	if _, err := testServer.Users.Get(ctx, userA); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected user to be purged, got %v", err)
+	}
+
+	// 他の人に書いたレビューは匿名化されて残り、受信者は読める
+	// （レビューの作品は退会したユーザーのものなので、作品は空になる）
+	req := httptest.NewRequest(http.MethodGet, "/reviews?user_id="+userB, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp []ReceivedReviewResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp) != 1 || resp[0].ReviewID != reviewID || !resp[0].IsAnonymous {
+		t.Fatalf("expected anonymized review, got %+v", resp)
+	}
+	if resp[0].Username != AnonymousUsername || resp[0].UserID != "" || resp[0].WorkID != "" || resp[0].Comment == "" {
+		t.Fatalf("unexpected anonymized review: %+v", resp[0])
+	}
+}
+
+func TestRestoreMyAccount(t *testing.T) {
+	r := setupTestRouter(withAccount)
+
+	userID := createTestUser(t)
+
+	if w := postJSON(r, http.MethodPost, "/me/restore", AccountRequest{UserID: userID, Password: "dummy"}); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 before deletion, got %d", w.Code)
+	}
+	if w := postJSON(r, http.MethodDelete, "/me", AccountRequest{UserID: userID, Password: "dummy"}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w := postJSON(r, http.MethodPost, "/me/restore", AccountRequest{UserID: userID, Password: "dummy"}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
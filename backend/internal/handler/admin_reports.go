@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+const (
+	defaultReportLimit = 50
+	maxReportLimit     = 200
+)
+
+type ReportResponse struct {
+	ID             string  `json:"id"`
+	ReporterID     *string `json:"reporter_id"` // システムによる自動通報なら null
+	TargetType     string  `json:"target_type"`
+	TargetID       string  `json:"target_id"`
+	Reason         string  `json:"reason"`
+	Note           string  `json:"note"`
+	Status         string  `json:"status"`
+	Action         *string `json:"action"`
+	HandledBy      *string `json:"handled_by"`
+	ResolutionNote string  `json:"resolution_note"`
+	ReportCount    int     `json:"report_count"` // 同じ対象への未処理の通報数
+	CreatedAt      string  `json:"created_at"`
+}
+
+type AdminRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+type ResolveReportRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Action string `json:"action" binding:"required"` // none / hide_review / hide_work / suspend_user
+	Note   string `json:"note" binding:"max=1000"`   // MaxReportNoteLength
+}
+
+// requireAdmin は管理者でなければ 403 を返して false
+func (s *Server) requireAdmin(c *gin.Context, userID string) bool {
+	if !service.IsAdmin(context.Background(), s.Users, userID) {
+		apierror.Abort(c, apierror.New(apierror.CodeAdminOnly))
+		return false
+	}
+	return true
+}
+
+// GetReports は通報の一覧を古い順に返す（既定は未処理と確認中）
+func (s *Server) GetReports(c *gin.Context) {
+	adminID, ok := queryUUID(c, "user_id")
+	if !ok || !s.requireAdmin(c, adminID) {
+		return
+	}
+
+	statuses := []string{service.ReportStatusOpen, service.ReportStatusTriaged}
+	if v := c.Query("status"); v != "" {
+		if !service.IsReportStatus(v) {
+			apierror.Abort(c, apierror.Invalid("status"))
+			return
+		}
+		statuses = []string{v}
+	}
+
+	limit := defaultReportLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("limit"))
+			return
+		}
+		limit = min(n, maxReportLimit)
+	}
+
+	list, err := s.Reports.List(context.Background(), statuses, limit)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	reports := []ReportResponse{}
+	for _, r := range list {
+		reports = append(reports, ReportResponse{
+			ID:             r.ID,
+			ReporterID:     r.ReporterID,
+			TargetType:     r.TargetType,
+			TargetID:       r.TargetID,
+			Reason:         r.Reason,
+			Note:           r.Note,
+			Status:         r.Status,
+			Action:         r.Action,
+			HandledBy:      r.HandledBy,
+			ResolutionNote: r.ResolutionNote,
+			ReportCount:    r.ReportCount,
+			CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// TriageReport は通報を確認中にする（担当者を記録する）
+func (s *Server) TriageReport(c *gin.Context) {
+	reportID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req AdminRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !s.requireAdmin(c, req.UserID) {
+		return
+	}
+
+	err := s.Reports.Triage(context.Background(), reportID, req.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeReportFinalized))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "report triaged"})
+}
+
+// ResolveReport は通報に対応（レビュー非表示・作品非表示・ユーザー停止）して閉じる
+func (s *Server) ResolveReport(c *gin.Context) {
+	reportID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ResolveReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !s.requireAdmin(c, req.UserID) {
+		return
+	}
+	if !service.IsModerationAction(req.Action) {
+		apierror.Abort(c, apierror.Invalid("action"))
+		return
+	}
+
+	err := service.ResolveReport(context.Background(), s.Reports, reportID, req.UserID, req.Action, req.Note)
+	switch {
+	case errors.Is(err, service.ErrReportNotFound):
+		apierror.Abort(c, apierror.New(apierror.CodeReportNotFound))
+		return
+	case errors.Is(err, service.ErrTargetNotFound):
+		apierror.Abort(c, apierror.New(apierror.CodeTargetNotFound))
+		return
+	case errors.Is(err, service.ErrInvalidAction):
+		apierror.Abort(c, apierror.Invalid("action"))
+		return
+	case errors.Is(err, service.ErrReportFinalized):
+		apierror.Abort(c, apierror.New(apierror.CodeReportFinalized))
+		return
+	case err != nil:
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "report resolved"})
+}
@@ -5,38 +5,39 @@ package handler
 import (
 	"context"
 	"net/http"
-
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
-func Login(c *gin.Context) {
+func (s *Server) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	var id, password string
-	err := db.Pool.QueryRow(context.Background(),
-		"SELECT id, password FROM public.users WHERE LOWER(email)=$1",
-		strings.ToLower(req.Email)).Scan(&id, &password)
+	user, err := s.Users.FindByEmail(context.Background(), req.Email)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		apierror.Abort(c, apierror.New(apierror.CodeInvalidCredentials))
+		return
+	}
+
+	if user.Password != req.Password {
+		apierror.Abort(c, apierror.New(apierror.CodeWrongPassword))
 		return
 	}
 
-	if password != req.Password {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "wrong password"})
+	// 退会申請中なら、アプリで取り消し（POST /me/restore）を案内できるようにする
+	if user.DeletedAt != nil {
+		c.JSON(http.StatusOK, gin.H{"user_id": user.ID, "deletion_scheduled_at": user.DeletedAt.Format(time.RFC3339)})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"user_id": id})
+	c.JSON(http.StatusOK, gin.H{"user_id": user.ID})
 }
@@ -4,15 +4,12 @@ package handler
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
-
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
 )
 
 func TestLoginSuccess(t *testing.T) {
@@ -45,13 +42,7 @@ func TestLoginSuccess(t *testing.T) {
 	}
 	json.Unmarshal(wSignup.Body.Bytes(), &signupResp)
 
-	t.Cleanup(func() {
-		db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.users WHERE id=$1",
-			signupResp.UserID,
-		)
-	})
+	cleanupRow(t, "users", signupResp.UserID)
 
 	// --- ログイン ---
 	loginBody := map[string]string{
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/validate"
+)
+
+// bindJSON は JSON ボディを読み込んで binding タグで検証する
+// だめな項目はまとめて 400 で返して false
+func bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		apierror.Abort(c, validate.Error(err))
+		return false
+	}
+	return true
+}
+
+// bindForm は multipart/form-data（ファイルを含む）を読み込んで検証する
+func bindForm(c *gin.Context, req any) bool {
+	if err := c.ShouldBind(req); err != nil {
+		apierror.Abort(c, validate.Error(err))
+		return false
+	}
+	return true
+}
+
+// queryUUID は必須の UUID クエリパラメータ（user_id など）を取り出す
+func queryUUID(c *gin.Context, name string) (string, bool) {
+	return checkUUID(c, name, c.Query(name), true)
+}
+
+// optionalQueryUUID は省略できる UUID クエリパラメータを取り出す（なければ ""）
+func optionalQueryUUID(c *gin.Context, name string) (string, bool) {
+	return checkUUID(c, name, c.Query(name), false)
+}
+
+// paramUUID はパスの UUID（/reviews/:id など）を取り出す
+func paramUUID(c *gin.Context, name string) (string, bool) {
+	return checkUUID(c, name, c.Param(name), true)
+}
+
+func checkUUID(c *gin.Context, name, v string, required bool) (string, bool) {
+	switch {
+	case v == "" && required:
+		apierror.Abort(c, apierror.Required(name))
+		return "", false
+	case v != "" && !validate.IsUUID(v):
+		apierror.Abort(c, apierror.Invalid(name))
+		return "", false
+	}
+	return v, true
+}
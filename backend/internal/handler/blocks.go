@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+type BlockRequest struct {
+	UserID        string `json:"user_id" binding:"required,uuid"`
+	BlockedUserID string `json:"blocked_user_id" binding:"required,uuid"`
+}
+
+type BlockedUserResponse struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	IconURL   string `json:"icon_url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// BlockUser は相手をブロックする（メッセージのやり取りができなくなる）
+func (s *Server) BlockUser(c *gin.Context) {
+	var req BlockRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.UserID == req.BlockedUserID {
+		apierror.Abort(c, apierror.New(apierror.CodeCannotTargetSelf))
+		return
+	}
+
+	err := s.Blocks.Block(context.Background(), req.UserID, req.BlockedUserID)
+	if errors.Is(err, repository.ErrMissingReference) {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "user blocked"})
+}
+
+// UnblockUser はブロックを解除する
+func (s *Server) UnblockUser(c *gin.Context) {
+	var req BlockRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := s.Blocks.Unblock(context.Background(), req.UserID, req.BlockedUserID); err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
+}
+
+// GetBlockedUsers は自分がブロックしているユーザー一覧を返す
+func (s *Server) GetBlockedUsers(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	blocked, err := s.Blocks.List(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	users := []BlockedUserResponse{}
+	for _, b := range blocked {
+		u := BlockedUserResponse{
+			UserID:    b.UserID,
+			Username:  b.Username,
+			CreatedAt: b.CreatedAt.Format(time.RFC3339),
+		}
+		if b.IconPath != nil {
+			u.IconURL = s.Storage.PublicURL(*b.IconPath)
+		} else {
+			u.IconURL = s.Storage.PublicURL(DefaultIconPath)
+		}
+		users = append(users, u)
+	}
+
+	c.JSON(http.StatusOK, users)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/validate"
+)
+
+// encodeCursor は next_cursor に入れる文字列を作る（クライアントは中身を見ずにそのまま返す）
+func encodeCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.Format(time.RFC3339Nano) + "|" + id))
+}
+
+// queryCursor は cursor クエリパラメータを読む。なければ nil
+// (created_at, id) のキーセットなので、created_at が同じ行があってもページの境目で抜けや重複が出ない
+// 形式が正しくなければ 400 を返して ok=false
+func queryCursor(c *gin.Context) (*repository.Cursor, bool) {
+	v := c.Query("cursor")
+	if v == "" {
+		return nil, true
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err == nil {
+		ts, id, found := strings.Cut(string(raw), "|")
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if found && err == nil && validate.IsUUID(id) {
+			return &repository.Cursor{CreatedAt: t, ID: id}, true
+		}
+	}
+
+	apierror.Abort(c, apierror.Invalid("cursor"))
+	return nil, false
+}
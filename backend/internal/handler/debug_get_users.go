@@ -5,7 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type UserInfo struct {
@@ -14,23 +14,16 @@ type UserInfo struct {
 }
 
 // DebugGetUsers 全ユーザーのIDとEmailを返すデバッグ用
-func DebugGetUsers(c *gin.Context) {
-	rows, err := db.Pool.Query(context.Background(),
-		"SELECT id, email FROM public.users")
+func (s *Server) DebugGetUsers(c *gin.Context) {
+	list, err := s.Users.List(context.Background())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query users"})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	users := []UserInfo{}
-	for rows.Next() {
-		var u UserInfo
-		if err := rows.Scan(&u.ID, &u.Email); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to scan user"})
-			return
-		}
-		users = append(users, u)
+	for _, u := range list {
+		users = append(users, UserInfo{ID: u.ID, Email: u.Email})
 	}
 
 	c.JSON(http.StatusOK, gin.H{"users": users})
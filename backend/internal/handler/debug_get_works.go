@@ -5,8 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type DebugWork struct {
@@ -18,43 +17,28 @@ type DebugWork struct {
 	Description string `json:"description"`
 }
 
-func DebugGetWorks(c *gin.Context) {
-	rows, err := db.Pool.Query(context.Background(), `
-		SELECT
-			w.id,
-			w.user_id,
-			u.username,
-			w.image_path,
-			w.title,
-			w.description
-		FROM public.works w
-		JOIN public.users u ON u.id = w.user_id
-		ORDER BY w.created_at DESC
-	`)
+func (s *Server) DebugGetWorks(c *gin.Context) {
+	list, err := s.Works.ListAll(context.Background())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	works := []DebugWork{}
-
-	for rows.Next() {
-		var w DebugWork
-		var imagePath string
-		if err := rows.Scan(
-			&w.ID,
-			&w.UserID,
-			&w.Username,
-			&imagePath,
-			&w.Title,
-			&w.Description,
-		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	for _, w := range list {
+		dw := DebugWork{
+			ID:       w.ID,
+			UserID:   w.UserID,
+			Username: w.Username,
+			Title:    w.Title,
+		}
+		if w.ImagePath != nil {
+			dw.ImageURL = s.Storage.PublicURL(*w.ImagePath)
+		}
+		if w.Description != nil {
+			dw.Description = *w.Description
 		}
-		w.ImageURL = lib.BuildPublicURL(imagePath)
-		works = append(works, w)
+		works = append(works, dw)
 	}
 
 	c.JSON(http.StatusOK, works)
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+type RegisterDeviceRequest struct {
+	UserID   string `json:"user_id" binding:"required,uuid"`
+	Token    string `json:"token" binding:"required,max=4096"`
+	Platform string `json:"platform" binding:"required"`
+}
+
+type UnregisterDeviceRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Token  string `json:"token" binding:"required"`
+}
+
+// RegisterDevice はプッシュ通知用の端末トークンを登録する
+// 同じトークンが別ユーザーで登録済みなら付け替える（端末でのログインし直し）
+func (s *Server) RegisterDevice(c *gin.Context) {
+	var req RegisterDeviceRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !notify.IsPlatform(req.Platform) {
+		apierror.Abort(c, apierror.Invalid("platform"))
+		return
+	}
+
+	err := s.Devices.Register(context.Background(), req.UserID, notify.Device{Token: req.Token, Platform: req.Platform})
+	if errors.Is(err, repository.ErrMissingReference) {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "device registered"})
+}
+
+// UnregisterDevice はログアウト時などに端末トークンを削除する
+func (s *Server) UnregisterDevice(c *gin.Context) {
+	var req UnregisterDeviceRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := s.Devices.Unregister(context.Background(), req.UserID, req.Token); err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "device unregistered"})
+}
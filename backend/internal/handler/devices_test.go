@@ -0,0 +1,105 @@
+// backend/internal/handler/devices_test.go
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+func TestRegisterDevice_Success(t *testing.T) {
+	r := setupTestRouter(withDevices)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	token := fmt.Sprintf("test-token-%d", time.Now().UnixNano())
+
+	w := postJSON(r, http.MethodPost, "/devices", RegisterDeviceRequest{UserID: userA, Token: token, Platform: notify.PlatformIOS})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 同じ端末で別ユーザーがログインしたら付け替わる
+	w = postJSON(r, http.MethodPost, "/devices", RegisterDeviceRequest{UserID: userB, Token: token, Platform: notify.PlatformIOS})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for userID, want := range map[string]int{userA: 0, userB: 1} {
+		devices, err := testServer.Devices.DevicesForUser(context.Background(), userID)
+		if err != nil {
+			t.Fatalf("failed to list devices: %v", err)
+		}
+		if len(devices) != want {
+			t.Fatalf("expected %d devices for %s, got %+v", want, userID, devices)
+		}
+	}
+
+	w = postJSON(r, http.MethodDelete, "/devices", UnregisterDeviceRequest{UserID: userB, Token: token})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRegisterDevice_InvalidPlatform(t *testing.T) {
+	r := setupTestRouter(withDevices)
+
+	userID := createTestUser(t)
+
+	w := postJSON(r, http.MethodPost, "/devices", RegisterDeviceRequest{UserID: userID, Token: "t", Platform: "symbian"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestNotificationIsPushedToRegisteredDevice(t *testing.T) {
+	r := setupTestRouter(withDevices)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	validToken := fmt.Sprintf("valid-%d", time.Now().UnixNano())
+	staleToken := fmt.Sprintf("stale-%d", time.Now().UnixNano())
+
+	postJSON(r, http.MethodPost, "/devices", RegisterDeviceRequest{UserID: userA, Token: validToken, Platform: notify.PlatformAndroid})
+	postJSON(r, http.MethodPost, "/devices", RegisterDeviceRequest{UserID: userA, Token: staleToken, Platform: notify.PlatformIOS})
+
+	pusher := notify.NewFakePusher()
+	pusher.MarkInvalid(staleToken)
+	notifier := service.NewNotifier(testServer.Repositories, testServer.Broker)
+	notifier.Push = notify.NewDispatcher(pusher, testServer.Devices)
+	notifier.Push.Backoff = time.Millisecond
+
+	notifier.Notify(context.Background(), service.Notification{
+		UserID:      userA,
+		Type:        service.NotificationMatchCreated,
+		ActorUserID: userB,
+	})
+
+	sent := pusher.Sent()
+	if len(sent) != 1 || sent[0].Token != validToken {
+		t.Fatalf("unexpected pushes: %+v", sent)
+	}
+	if sent[0].Data["type"] != service.NotificationMatchCreated {
+		t.Fatalf("unexpected data: %+v", sent[0].Data)
+	}
+
+	// 無効なトークンは削除される
+	devices, err := testServer.Devices.DevicesForUser(context.Background(), userA)
+	if err != nil {
+		t.Fatalf("failed to list devices: %v", err)
+	}
+	if len(devices) != 1 || devices[0].Token != validToken {
+		t.Fatalf("expected stale token to be removed, got %+v", devices)
+	}
+
+	// プッシュとは別に受信箱にも残る
+	unread, err := testServer.Notifications.CountUnread(context.Background(), userA)
+	if err != nil || unread != 1 {
+		t.Fatalf("expected 1 unread notification, got %d (err %v)", unread, err)
+	}
+}
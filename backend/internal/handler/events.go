@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"golang.org/x/net/websocket"
+)
+
+// eventsHeartbeat は接続維持のために送る ping の間隔
+const eventsHeartbeat = 25 * time.Second
+
+// StreamEvents はログインユーザー宛てのイベントをリアルタイムに配信する
+// 既定は Server-Sent Events、transport=websocket または Upgrade ヘッダ付きなら WebSocket
+func (s *Server) StreamEvents(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if c.Query("transport") == "websocket" || c.GetHeader("Upgrade") == "websocket" {
+		streamEventsWebSocket(c, s.Broker, userID, s.closing)
+		return
+	}
+
+	streamEventsSSE(c, s.Broker, userID, s.closing)
+}
+
+func streamEventsSSE(c *gin.Context, broker realtime.Broker, userID string, closing <-chan struct{}) {
+	events, unsubscribe := broker.Subscribe(userID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// 張りっぱなしの接続なので、サーバー全体の WriteTimeout で切られないようにする
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	// 接続直後にヘッダを送っておく
+	fmt.Fprint(c.Writer, ": connected\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-closing:
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+			c.Writer.Flush()
+		}
+	}
+}
+
+func streamEventsWebSocket(c *gin.Context, broker realtime.Broker, userID string, closing <-chan struct{}) {
+	server := websocket.Server{
+		// モバイルアプリは Origin を送らないため検証しない（CORS も全許可）
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			events, unsubscribe := broker.Subscribe(userID)
+			defer unsubscribe()
+
+			// クライアントからの切断を検知するため受信を読み捨てる
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				var discard string
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			heartbeat := time.NewTicker(eventsHeartbeat)
+			defer heartbeat.Stop()
+
+			for {
+				select {
+				case <-closed:
+					return
+				case <-closing:
+					return
+				case <-heartbeat.C:
+					if err := websocket.JSON.Send(ws, gin.H{"type": "ping"}); err != nil {
+						return
+					}
+				case ev, ok := <-events:
+					if !ok {
+						return
+					}
+					if err := websocket.JSON.Send(ws, ev); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,106 @@
+// backend/internal/handler/events_test.go
+package handler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+)
+
+func TestStreamEvents_SSE(t *testing.T) {
+	r := setupTestRouter(withEvents)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	userID := createTestUser(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events?user_id="+userID, nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("unexpected content-type: %s", ct)
+	}
+
+	reader := bufio.NewReader(res.Body)
+
+	// 接続確立のコメント行を待ってから配信する
+	if line, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(line, ":") {
+		t.Fatalf("expected connected comment, got %q (%v)", line, err)
+	}
+
+	realtime.Publish(ctx, testServer.Broker, realtime.NewEvent(realtime.EventMatchCreated, userID, map[string]any{"match_id": "dummy"}))
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if strings.HasPrefix(line, "event: ") {
+			if got := strings.TrimSpace(strings.TrimPrefix(line, "event: ")); got != realtime.EventMatchCreated {
+				t.Fatalf("unexpected event type: %s", got)
+			}
+			return
+		}
+	}
+}
+
+func TestStreamEvents_MissingUserID(t *testing.T) {
+	r := setupTestRouter(withEvents)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestStreamEvents_ClosedOnShutdown(t *testing.T) {
+	s := NewServer(testServer.Repositories, testServer.Config)
+	r := setupTestRouter(func(r *gin.Engine) { r.GET("/events", s.StreamEvents) })
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	userID := createTestUser(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events?user_id="+userID, nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	reader := bufio.NewReader(res.Body)
+	if _, err := reader.ReadString('\n'); err != nil {
+		t.Fatalf("failed to read connected comment: %v", err)
+	}
+
+	s.CloseStreams()
+
+	// 終了時はサーバー側から接続を閉じる
+	if _, err := io.ReadAll(reader); err != nil {
+		t.Fatalf("stream should end cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/handle"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+const (
+	// HandleChangeInterval はハンドルを変えてから次に変えられるまでの期間
+	HandleChangeInterval = 30 * 24 * time.Hour
+	// HandleReleaseAfter は手放したハンドルを他の人が使えるようになるまでの期間
+	// それまでは古いハンドルへのアクセスを今のハンドルへ転送する
+	HandleReleaseAfter = 90 * 24 * time.Hour
+)
+
+type UpdateHandleRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Handle string `json:"handle" binding:"required"`
+}
+
+type HandleResponse struct {
+	UserID string `json:"user_id"`
+	Handle string `json:"handle"`
+}
+
+// UpdateMyHandle はハンドルを設定・変更する（初回以外は HandleChangeInterval に 1 回まで）
+func (s *Server) UpdateMyHandle(c *gin.Context) {
+	var req UpdateHandleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h := handle.Normalize(req.Handle)
+	switch err := handle.Validate(h); {
+	case errors.Is(err, handle.ErrReserved):
+		apierror.Abort(c, apierror.New(apierror.CodeReservedHandle))
+		return
+	case err != nil:
+		apierror.Abort(c, apierror.New(apierror.CodeInvalidHandle).WithDetails([]apierror.FieldError{
+			{Field: "handle", Reason: apierror.ReasonInvalid},
+		}))
+		return
+	}
+
+	// 他の人の以前のハンドルは一定期間使えない（自分の以前のハンドルには戻せる）
+	heldSince := time.Now().Add(-HandleReleaseAfter)
+	_, err := s.Handles.Change(context.Background(), req.UserID, h, heldSince, func(current *string, changedAt *time.Time) error {
+		if current != nil && changedAt != nil {
+			if next := changedAt.Add(HandleChangeInterval); time.Now().Before(next) {
+				return apierror.New(apierror.CodeHandleChangeTooSoon).WithDetails(gin.H{
+					"next_change_at": next.Format(time.RFC3339),
+				})
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	case errors.Is(err, repository.ErrConflict):
+		// 使用中・手放されて間もない
+		apierror.Abort(c, apierror.New(apierror.CodeHandleTaken))
+		return
+	case err != nil:
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, HandleResponse{UserID: req.UserID, Handle: h})
+}
+
+// ResolveHandle はハンドルからユーザーを引く
+// 以前のハンドルなら今のハンドルへ 301 で転送する
+func (s *Server) ResolveHandle(c *gin.Context) {
+	h := handle.Normalize(c.Param("handle"))
+
+	ctx := context.Background()
+
+	userID, err := s.Handles.Find(ctx, h)
+	if err == nil {
+		c.JSON(http.StatusOK, HandleResponse{UserID: userID, Handle: h})
+		return
+	}
+
+	current, err := s.Handles.FindPrevious(ctx, h, time.Now().Add(-HandleReleaseAfter))
+	if err != nil {
+		apierror.Abort(c, apierror.New(apierror.CodeHandleNotFound))
+		return
+	}
+
+	c.Redirect(http.StatusMovedPermanently, "/handles/"+current)
+}
@@ -0,0 +1,64 @@
+// backend/internal/handler/handle_test.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testHandle() string {
+	return fmt.Sprintf("t%d", time.Now().UnixNano()%1e12)
+}
+
+func TestUpdateMyHandle_HistoryAndRedirect(t *testing.T) {
+	r := setupTestRouter(withHandles)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+
+	first := testHandle()
+	second := testHandle()
+
+	// 初回の設定は変更回数に数えない
+	if w := postJSON(r, http.MethodPut, "/me/handle", UpdateHandleRequest{UserID: userA, Handle: "@" + first}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := postJSON(r, http.MethodPut, "/me/handle", UpdateHandleRequest{UserID: userA, Handle: second}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 続けての変更は制限される
+	if w := postJSON(r, http.MethodPut, "/me/handle", UpdateHandleRequest{UserID: userA, Handle: testHandle()}); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+
+	// 古いハンドルは今のハンドルへ転送される
+	req := httptest.NewRequest(http.MethodGet, "/handles/"+first, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "/handles/"+second {
+		t.Fatalf("expected redirect to %s, got %d %s", second, w.Code, w.Header().Get("Location"))
+	}
+
+	// 他の人は古いハンドルも今のハンドルも使えない
+	for _, h := range []string{first, second} {
+		if w := postJSON(r, http.MethodPut, "/me/handle", UpdateHandleRequest{UserID: userB, Handle: h}); w.Code != http.StatusConflict {
+			t.Fatalf("expected 409 for %s, got %d", h, w.Code)
+		}
+	}
+}
+
+func TestUpdateMyHandle_Invalid(t *testing.T) {
+	r := setupTestRouter(withHandles)
+
+	userID := createTestUser(t)
+
+	for _, h := range []string{"ab", "kira-art", "admin"} {
+		if w := postJSON(r, http.MethodPut, "/me/handle", UpdateHandleRequest{UserID: userID, Handle: h}); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %q, got %d", h, w.Code)
+		}
+	}
+}
@@ -1,15 +1,40 @@
 package handler
 
 import (
+	"context"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/p2hacks2025/pre-12/backend/internal/config"
 	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
+// TEST_BACKEND でテストに使うデータの置き場所を選ぶ
+//   - memory: メモリ上のリポジトリ（DB なしで動く）
+//   - postgres: DATABASE_URL の Postgres
+//
+// 未指定なら DATABASE_URL があれば postgres、なければ memory
+func testBackend() string {
+	if b := os.Getenv("TEST_BACKEND"); b != "" {
+		return b
+	}
+	if os.Getenv("DATABASE_URL") != "" {
+		return "postgres"
+	}
+	return "memory"
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
@@ -17,7 +42,100 @@ func TestMain(m *testing.M) {
 		log.Println(".env not found, relying on environment variables")
 	}
 
-	db.Init()
+	cfg, err := config.FromEnv(config.ProfileTest, os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	serverCfg := Config{Storage: storage.Config{
+		URL:            cfg.Supabase.URL,
+		ServiceRoleKey: cfg.Supabase.ServiceRoleKey,
+	}}
+	// Supabase の設定がなければ、画像はメモリ上の Storage に置く
+	if serverCfg.Storage.URL == "" {
+		serverCfg.Storage.URL = httptest.NewServer(newFakeStorage()).URL
+	}
+
+	switch backend := testBackend(); backend {
+	case "memory":
+		testServer = NewServer(repository.NewMemory(), serverCfg)
+	case "postgres":
+		pool, err := db.Connect(context.Background(), cfg.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		testPool = pool
+		testServer = NewServer(repository.NewPG(pool), serverCfg)
+	default:
+		log.Fatalf("unknown TEST_BACKEND: %s", backend)
+	}
 
 	os.Exit(m.Run())
 }
+
+// fakeStorage は Supabase Storage のオブジェクト API（/storage/v1/object/{bucket}/{path}）の代わり
+type fakeStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{objects: make(map[string][]byte)}
+}
+
+func (f *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/storage/v1/object/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// freezeCreatedAt は、これから作る行の created_at がすべて at になるようにする（同じ時刻の行のテスト用）
+// メモリ上のリポジトリでは、このテストの間だけ時刻を止めたリポジトリに差し替える
+// Postgres では行を作ったあとで、返した align で table の column = id の行の created_at を揃える
+func freezeCreatedAt(t *testing.T, at time.Time) (align func(table, column, id string)) {
+	t.Helper()
+
+	if testPool == nil {
+		prev := testServer
+		testServer = NewServer(repository.NewMemoryAt(func() time.Time { return at }), prev.Config)
+		t.Cleanup(func() { testServer = prev })
+		return func(string, string, string) {}
+	}
+
+	return func(table, column, id string) {
+		t.Helper()
+		if _, err := testPool.Exec(context.Background(),
+			"UPDATE public."+table+" SET created_at = $1 WHERE "+column+" = $2", at, id,
+		); err != nil {
+			t.Fatalf("failed to align created_at: %v", err)
+		}
+	}
+}
@@ -5,8 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type MatchResponse struct {
@@ -19,82 +18,44 @@ type MatchResponse struct {
 	IsReviewed   bool   `json:"is_reviewed"`
 }
 
-func GetMatches(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+func (s *Server) GetMatches(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
 	ctx := context.Background()
 
-	rows, err := db.Pool.Query(ctx, `
-		SELECT
-		  m.id AS match_id,
-		  u.id AS user_id,
-		  u.username,
-		  u.icon_path,
-		  w.image_path AS work_image_path,
-		  w.title AS work_title,
-		  EXISTS (
-		    SELECT 1
-		    FROM public.reviews r
-		    WHERE r.match_id = m.id
-		      AND r.from_user_id = $1
-		  ) AS is_reviewed
-		FROM public.matches m
-		JOIN public.users u
-		  ON u.id = CASE
-		    WHEN m.user1_id = $1 THEN m.user2_id
-		    ELSE m.user1_id
-		  END
-		JOIN public.works w
-		  ON w.id = CASE
-		    WHEN m.user1_id = $1 THEN m.work2_id
-		    ELSE m.work1_id
-		  END
-		WHERE $1 IN (m.user1_id, m.user2_id)
-		ORDER BY m.created_at DESC
-	`, userID)
-
+	list, err := s.Matches.ListForUser(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	matches := []MatchResponse{}
 
-	for rows.Next() {
-		var m MatchResponse
-		var iconPath, workPath *string
-
-		if err := rows.Scan(
-			&m.MatchID,
-			&m.UserID,
-			&m.Username,
-			&iconPath,
-			&workPath,
-			&m.WorkTitle,
-			&m.IsReviewed,
-		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	for _, match := range list {
+		m := MatchResponse{
+			MatchID:    match.MatchID,
+			UserID:     match.UserID,
+			Username:   match.Username,
+			WorkTitle:  match.WorkTitle,
+			IsReviewed: match.IsReviewed,
 		}
 
 		const DefaultIconPath = "icons/default.png"
 		const DefaultWorkImagePath = "images/default.png"
 
-		if iconPath != nil {
-			m.IconURL = lib.BuildPublicURL(*iconPath)
+		if match.IconPath != nil {
+			m.IconURL = s.Storage.PublicURL(*match.IconPath)
 		} else {
-			m.IconURL = lib.BuildPublicURL(DefaultIconPath)
+			m.IconURL = s.Storage.PublicURL(DefaultIconPath)
 		}
 
-		if workPath != nil {
-			m.WorkImageURL = lib.BuildPublicURL(*workPath)
+		if match.WorkImagePath != nil {
+			m.WorkImageURL = s.Storage.PublicURL(*match.WorkImagePath)
 		} else {
-			m.WorkImageURL = lib.BuildPublicURL(DefaultWorkImagePath)
+			m.WorkImageURL = s.Storage.PublicURL(DefaultWorkImagePath)
 		}
 
 		matches = append(matches, m)
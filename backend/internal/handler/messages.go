@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+const (
+	// MaxMessageLength はメッセージ本文の最大文字数
+	MaxMessageLength = 1000
+
+	defaultMessageLimit = 50
+	maxMessageLimit     = 100
+)
+
+type MessageResponse struct {
+	ID        string  `json:"id"`
+	MatchID   string  `json:"match_id"`
+	SenderID  string  `json:"sender_id"`
+	Body      string  `json:"body"`
+	IsMine    bool    `json:"is_mine"`
+	ReadAt    *string `json:"read_at"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type MessageListResponse struct {
+	Messages   []MessageResponse `json:"messages"`
+	NextCursor *string           `json:"next_cursor"` // 続きがなければ null
+}
+
+type PostMessageRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Body   string `json:"body" binding:"required,notblank,max=1000"` // MaxMessageLength
+}
+
+type MarkMessagesReadRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+// checkConversation はマッチのメッセージを userID が使えるか確認し、相手の ID を返す
+// Give-to-Get を崩さないよう、お互いにレビューを送り合うまではメッセージできない
+func (s *Server) checkConversation(ctx context.Context, matchID, userID string) (string, error) {
+	match, err := s.Matches.Get(ctx, matchID)
+	if err != nil {
+		return "", apierror.New(apierror.CodeMatchNotFound)
+	}
+
+	partnerID, _, ok := match.Partner(userID)
+	if !ok {
+		return "", apierror.New(apierror.CodeNotMatchMember)
+	}
+
+	for _, fromUserID := range []string{match.User1ID, match.User2ID} {
+		_, err := s.Reviews.FindID(ctx, matchID, fromUserID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", apierror.New(apierror.CodeMessagesLocked)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	blocked, err := s.Blocks.IsBlocked(ctx, userID, partnerID)
+	if err != nil {
+		return "", err
+	}
+	if blocked {
+		return "", apierror.New(apierror.CodeUserBlocked)
+	}
+
+	return partnerID, nil
+}
+
+// GetMessages はマッチ内のメッセージを新しい順に limit 件返す
+// cursor には前のページの next_cursor を渡す
+func (s *Server) GetMessages(c *gin.Context) {
+	matchID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	limit := defaultMessageLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("limit"))
+			return
+		}
+		limit = min(n, maxMessageLimit)
+	}
+
+	cursor, ok := queryCursor(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+
+	if _, err := s.checkConversation(ctx, matchID, userID); err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	// 1 件多く取って続きがあるか判定する
+	list, err := s.Messages.List(ctx, matchID, cursor, limit+1)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	resp := MessageListResponse{Messages: []MessageResponse{}}
+	for _, m := range list {
+		if len(resp.Messages) == limit {
+			last := list[limit-1]
+			next := encodeCursor(last.CreatedAt, last.ID)
+			resp.NextCursor = &next
+			break
+		}
+		resp.Messages = append(resp.Messages, newMessageResponse(m, userID))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PostMessage はマッチ相手にメッセージを送る
+func (s *Server) PostMessage(c *gin.Context) {
+	matchID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req PostMessageRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	body := strings.TrimSpace(req.Body)
+
+	ctx := context.Background()
+
+	partnerID, err := s.checkConversation(ctx, matchID, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	m, err := s.Messages.Create(ctx, repository.Message{MatchID: matchID, SenderID: req.UserID, Body: body})
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	resp := newMessageResponse(m, req.UserID)
+
+	// 相手と、自分の他の端末にも配信する
+	data := map[string]any{
+		"message_id": resp.ID,
+		"match_id":   resp.MatchID,
+		"sender_id":  resp.SenderID,
+		"body":       resp.Body,
+		"created_at": resp.CreatedAt,
+	}
+	realtime.Publish(ctx, s.Broker, realtime.NewEvent(realtime.EventMessageCreated, partnerID, data))
+	realtime.Publish(ctx, s.Broker, realtime.NewEvent(realtime.EventMessageCreated, req.UserID, data))
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// MarkMessagesRead は相手から届いた未読メッセージを既読にする（既読通知）
+func (s *Server) MarkMessagesRead(c *gin.Context) {
+	matchID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req MarkMessagesReadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx := context.Background()
+
+	partnerID, err := s.checkConversation(ctx, matchID, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	updated, err := s.Messages.MarkRead(ctx, matchID, partnerID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	if updated > 0 {
+		// 送信者に既読を知らせる
+		realtime.Publish(ctx, s.Broker, realtime.NewEvent(realtime.EventMessageRead, partnerID, map[string]any{
+			"match_id": matchID,
+			"user_id":  req.UserID,
+			"read_at":  time.Now().Format(time.RFC3339),
+		}))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func newMessageResponse(m repository.Message, userID string) MessageResponse {
+	return MessageResponse{
+		ID:        m.ID,
+		MatchID:   m.MatchID,
+		SenderID:  m.SenderID,
+		Body:      m.Body,
+		IsMine:    m.SenderID == userID,
+		ReadAt:    formatTimePtr(m.ReadAt),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,187 @@
+// backend/internal/handler/messages_test.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessages_LockedUntilBothReviewed(t *testing.T) {
+	r := setupTestRouter(withMessages)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	// A だけレビュー済み
+	_ = createTestReview(t, matchID, userA, userB, workB, "nice work!")
+
+	w := postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages", PostMessageRequest{UserID: userA, Body: "hello"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMessages_SendListAndRead(t *testing.T) {
+	r := setupTestRouter(withMessages)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	userC := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	_ = createTestReview(t, matchID, userA, userB, workB, "nice work!")
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	// --- 送信 ---
+	w := postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages", PostMessageRequest{UserID: userA, Body: "  hello  "})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var sent MessageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &sent); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if sent.Body != "hello" || !sent.IsMine || sent.ReadAt != nil {
+		t.Fatalf("unexpected message: %+v", sent)
+	}
+
+	// --- マッチ外のユーザーは読めない ---
+	req := httptest.NewRequest(http.MethodGet, "/matches/"+matchID+"/messages?user_id="+userC, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+
+	// --- B が既読にする ---
+	w = postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages/read", MarkMessagesReadRequest{UserID: userB})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// --- A から見ると既読になっている ---
+	req = httptest.NewRequest(http.MethodGet, "/matches/"+matchID+"/messages?user_id="+userA, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var list MessageListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if len(list.Messages) != 1 || list.NextCursor != nil {
+		t.Fatalf("expected 1 message, got %+v", list)
+	}
+	if list.Messages[0].ReadAt == nil {
+		t.Fatal("expected read_at to be set")
+	}
+}
+
+// 同じ時刻に送ったメッセージもページの境目で抜けたり重複したりしない
+func TestMessages_SameCreatedAt(t *testing.T) {
+	align := freezeCreatedAt(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := setupTestRouter(withMessages)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+	_ = createTestReview(t, matchID, userA, userB, workB, "nice work!")
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	for _, body := range []string{"one", "two", "three"} {
+		if w := postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages", PostMessageRequest{UserID: userA, Body: body}); w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	align("messages", "match_id", matchID)
+
+	seen := map[string]bool{}
+	query := url.Values{"user_id": {userB}, "limit": {"1"}}
+	for {
+		req := httptest.NewRequest(http.MethodGet, "/matches/"+matchID+"/messages?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var page MessageListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, m := range page.Messages {
+			if seen[m.ID] {
+				t.Fatalf("message %s returned twice", m.ID)
+			}
+			seen[m.ID] = true
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query.Set("cursor", *page.NextCursor)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(seen))
+	}
+}
+
+func TestMessages_TooLong(t *testing.T) {
+	r := setupTestRouter(withMessages)
+
+	userA := createTestUser(t)
+
+	w := postJSON(r, http.MethodPost, "/matches/00000000-0000-0000-0000-000000000000/messages", PostMessageRequest{
+		UserID: userA,
+		Body:   strings.Repeat("あ", MaxMessageLength+1),
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestMessages_BlockedUser(t *testing.T) {
+	r := setupTestRouter(withMessages, withBlocks)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	_ = createTestReview(t, matchID, userA, userB, workB, "nice work!")
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	w := postJSON(r, http.MethodPost, "/blocks", BlockRequest{UserID: userB, BlockedUserID: userA})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// ブロックされた側からも送れない
+	w = postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages", PostMessageRequest{UserID: userA, Body: "hello"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 解除すれば送れる
+	w = postJSON(r, http.MethodDelete, "/blocks", BlockRequest{UserID: userB, BlockedUserID: userA})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = postJSON(r, http.MethodPost, "/matches/"+matchID+"/messages", PostMessageRequest{UserID: userA, Body: "hello"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -6,8 +6,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type MyWorkResponse struct {
@@ -19,57 +18,40 @@ type MyWorkResponse struct {
 }
 
 // GetMyWorks - 指定ユーザーの作品一覧を返す
-func GetMyWorks(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+func (s *Server) GetMyWorks(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-
-	rows, err := db.Pool.Query(
-		ctx,
-		`SELECT id, image_path, title, description, created_at
-		 FROM public.works
-		 WHERE user_id = $1
-		 ORDER BY created_at DESC`,
-		userID,
-	)
+	list, err := s.Works.ListByUser(context.Background(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query works"})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	var works []MyWorkResponse
 
-	for rows.Next() {
-		var w MyWorkResponse
-		var imagePath, description *string
-		var createdAt time.Time
-		if err := rows.Scan(&w.ID, &imagePath, &w.Title, &description, &createdAt); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to scan work"})
-			return
-		}
+	for _, work := range list {
+		w := MyWorkResponse{ID: work.ID, Title: work.Title}
 
 		// 画像URL
 		const DefaultImagePath = "images/default.png"
 
-		if imagePath != nil {
-			w.ImageURL = lib.BuildPublicURL(*imagePath)
+		if work.ImagePath != nil {
+			w.ImageURL = s.Storage.PublicURL(*work.ImagePath)
 		} else {
-			w.ImageURL = lib.BuildPublicURL(DefaultImagePath)
+			w.ImageURL = s.Storage.PublicURL(DefaultImagePath)
 		}
 
 		// description が nil の場合は空文字にする
-		if description != nil {
-			w.Description = *description
+		if work.Description != nil {
+			w.Description = *work.Description
 		} else {
 			w.Description = ""
 		}
 
-		w.CreatedAt = createdAt.Format(time.RFC3339)
+		w.CreatedAt = work.CreatedAt.Format(time.RFC3339)
 
 		works = append(works, w)
 	}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+type NotificationResponse struct {
+	ID            string  `json:"id"`
+	Type          string  `json:"type"`
+	ActorUserID   *string `json:"actor_user_id"`
+	ActorUsername *string `json:"actor_username"`
+	ActorIconURL  *string `json:"actor_icon_url"`
+	MatchID       *string `json:"match_id"`
+	ReviewID      *string `json:"review_id"`
+	IsRead        bool    `json:"is_read"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+	NextCursor    *string                `json:"next_cursor"` // 続きがなければ null
+}
+
+type MarkNotificationsReadRequest struct {
+	UserID          string   `json:"user_id" binding:"required,uuid"`
+	NotificationIDs []string `json:"notification_ids" binding:"max=100,dive,uuid"`
+	All             bool     `json:"all"`
+}
+
+type NotificationPreference struct {
+	Type    string `json:"type" binding:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	UserID      string                   `json:"user_id" binding:"required,uuid"`
+	Preferences []NotificationPreference `json:"preferences" binding:"dive"`
+}
+
+// GetNotifications は通知の受信箱を新しい順に limit 件返す
+// cursor には前のページの next_cursor を渡す
+func (s *Server) GetNotifications(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	limit := defaultNotificationLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("limit"))
+			return
+		}
+		limit = min(n, maxNotificationLimit)
+	}
+
+	cursor, ok := queryCursor(c)
+	if !ok {
+		return
+	}
+
+	unreadOnly := c.Query("unread_only") == "true"
+
+	ctx := context.Background()
+
+	// 1 件多く取って続きがあるか判定する
+	list, err := s.Notifications.List(ctx, userID, cursor, unreadOnly, limit+1)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	resp := NotificationListResponse{Notifications: []NotificationResponse{}}
+
+	for _, item := range list {
+		if len(resp.Notifications) == limit {
+			last := list[limit-1]
+			next := encodeCursor(last.CreatedAt, last.ID)
+			resp.NextCursor = &next
+			break
+		}
+
+		n := NotificationResponse{
+			ID:            item.ID,
+			Type:          item.Type,
+			ActorUserID:   item.ActorUserID,
+			ActorUsername: item.ActorUsername,
+			MatchID:       item.MatchID,
+			ReviewID:      item.ReviewID,
+			IsRead:        item.IsRead,
+			CreatedAt:     item.CreatedAt.Format(time.RFC3339),
+		}
+
+		// 相手がいる通知だけアイコンを返す
+		if n.ActorUserID != nil {
+			iconURL := s.Storage.PublicURL(DefaultIconPath)
+			if item.ActorIconPath != nil {
+				iconURL = s.Storage.PublicURL(*item.ActorIconPath)
+			}
+			n.ActorIconURL = &iconURL
+		}
+
+		resp.Notifications = append(resp.Notifications, n)
+	}
+
+	resp.UnreadCount, err = s.Notifications.CountUnread(ctx, userID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUnreadNotificationCount は未読通知の件数だけを返す（バッジ表示用）
+func (s *Server) GetUnreadNotificationCount(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	count, err := s.Notifications.CountUnread(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread_count": count})
+}
+
+// MarkNotificationsRead は指定した通知（all=true なら全件）を既読にする
+func (s *Server) MarkNotificationsRead(c *gin.Context) {
+	var req MarkNotificationsReadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if !req.All && len(req.NotificationIDs) == 0 {
+		apierror.Abort(c, apierror.Required("notification_ids"))
+		return
+	}
+
+	ctx := context.Background()
+
+	// 他人の通知は既読にならない
+	updated, err := s.Notifications.MarkRead(ctx, req.UserID, req.NotificationIDs, req.All)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	count, err := s.Notifications.CountUnread(ctx, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"updated":      updated,
+		"unread_count": count,
+	})
+}
+
+// GetNotificationPreferences は種別ごとの通知設定を返す（未設定はオン）
+func (s *Server) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	prefs, err := s.loadNotificationPreferences(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdateNotificationPreferences は種別ごとの通知設定を上書きする
+// 送られてこなかった種別は変更しない
+func (s *Server) UpdateNotificationPreferences(c *gin.Context) {
+	var req UpdateNotificationPreferencesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	var invalid []apierror.FieldError
+	for i, p := range req.Preferences {
+		if !service.IsNotificationType(p.Type) {
+			invalid = append(invalid, apierror.FieldError{
+				Field:  fmt.Sprintf("preferences[%d].type", i),
+				Reason: apierror.ReasonInvalid,
+			})
+		}
+	}
+	if len(invalid) > 0 {
+		apierror.Abort(c, apierror.Validation(invalid))
+		return
+	}
+
+	ctx := context.Background()
+
+	for _, p := range req.Preferences {
+		err := s.Notifications.SetPreference(ctx, req.UserID, p.Type, p.Enabled)
+		if errors.Is(err, repository.ErrMissingReference) {
+			apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Abort(c, err)
+			return
+		}
+	}
+
+	prefs, err := s.loadNotificationPreferences(ctx, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (s *Server) loadNotificationPreferences(ctx context.Context, userID string) ([]NotificationPreference, error) {
+	saved, err := s.Notifications.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make([]NotificationPreference, 0, len(service.NotificationTypes))
+	for _, t := range service.NotificationTypes {
+		enabled, ok := saved[t]
+		if !ok {
+			enabled = true
+		}
+		prefs = append(prefs, NotificationPreference{Type: t, Enabled: enabled})
+	}
+
+	return prefs, nil
+}
@@ -0,0 +1,231 @@
+// backend/internal/handler/notifications_test.go
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+func TestNotifications_ListAndMarkRead(t *testing.T) {
+	r := setupTestRouter(withNotifications)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+	reviewID := createTestReview(t, matchID, userB, userA, workA, "nice work!")
+
+	ctx := context.Background()
+	testServer.Notifier.Notify(ctx, service.Notification{
+		UserID:      userA,
+		Type:        service.NotificationMatchCreated,
+		ActorUserID: userB,
+		MatchID:     matchID,
+	})
+	testServer.Notifier.Notify(ctx, service.Notification{
+		UserID:      userA,
+		Type:        service.NotificationReviewReceived,
+		ActorUserID: userB,
+		MatchID:     matchID,
+		ReviewID:    reviewID,
+	})
+
+	// --- 一覧 ---
+	req := httptest.NewRequest(http.MethodGet, "/notifications?user_id="+userA, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var list NotificationListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(list.Notifications) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(list.Notifications))
+	}
+	if list.UnreadCount != 2 {
+		t.Fatalf("expected unread_count=2, got %d", list.UnreadCount)
+	}
+	for _, n := range list.Notifications {
+		if n.ActorUserID == nil || *n.ActorUserID != userB {
+			t.Fatalf("unexpected actor: %+v", n)
+		}
+		if n.ActorIconURL == nil || *n.ActorIconURL == "" {
+			t.Fatalf("expected actor icon url: %+v", n)
+		}
+	}
+
+	// --- 1 件だけ既読 ---
+	body, _ := json.Marshal(MarkNotificationsReadRequest{
+		UserID:          userA,
+		NotificationIDs: []string{list.Notifications[0].ID},
+	})
+	req = httptest.NewRequest(http.MethodPost, "/notifications/read", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/notifications/unread-count?user_id="+userA, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var count struct {
+		UnreadCount int `json:"unread_count"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &count)
+	if count.UnreadCount != 1 {
+		t.Fatalf("expected unread_count=1, got %d", count.UnreadCount)
+	}
+
+	// --- 他人は既読にできない ---
+	body, _ = json.Marshal(MarkNotificationsReadRequest{UserID: userB, All: true})
+	req = httptest.NewRequest(http.MethodPost, "/notifications/read", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	req = httptest.NewRequest(http.MethodGet, "/notifications/unread-count?user_id="+userA, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	_ = json.Unmarshal(w.Body.Bytes(), &count)
+	if count.UnreadCount != 1 {
+		t.Fatalf("expected unread_count to stay 1, got %d", count.UnreadCount)
+	}
+}
+
+func TestNotifications_PreferenceDisablesType(t *testing.T) {
+	r := setupTestRouter(withNotifications)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+
+	// match.created をオフにする
+	body, _ := json.Marshal(UpdateNotificationPreferencesRequest{
+		UserID: userA,
+		Preferences: []NotificationPreference{
+			{Type: service.NotificationMatchCreated, Enabled: false},
+		},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var prefs []NotificationPreference
+	if err := json.Unmarshal(w.Body.Bytes(), &prefs); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(prefs) != len(service.NotificationTypes) {
+		t.Fatalf("expected %d preferences, got %d", len(service.NotificationTypes), len(prefs))
+	}
+	for _, p := range prefs {
+		if p.Enabled != (p.Type != service.NotificationMatchCreated) {
+			t.Fatalf("unexpected preference: %+v", p)
+		}
+	}
+
+	testServer.Notifier.Notify(context.Background(), service.Notification{
+		UserID:      userA,
+		Type:        service.NotificationMatchCreated,
+		ActorUserID: userB,
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/notifications?user_id="+userA, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var list NotificationListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if len(list.Notifications) != 0 {
+		t.Fatalf("expected no notifications, got %d", len(list.Notifications))
+	}
+}
+
+func TestNotifications_UnknownPreferenceType(t *testing.T) {
+	r := setupTestRouter(withNotifications)
+
+	userID := createTestUser(t)
+
+	body, _ := json.Marshal(UpdateNotificationPreferencesRequest{
+		UserID:      userID,
+		Preferences: []NotificationPreference{{Type: "unknown", Enabled: false}},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+// 同じ時刻に作られた通知もページの境目で抜けたり重複したりしない
+func TestNotifications_SameCreatedAt(t *testing.T) {
+	align := freezeCreatedAt(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := setupTestRouter(withNotifications)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		testServer.Notifier.Notify(ctx, service.Notification{
+			UserID:      userA,
+			Type:        service.NotificationMatchCreated,
+			ActorUserID: userB,
+		})
+	}
+	align("notifications", "user_id", userA)
+
+	seen := map[string]bool{}
+	query := url.Values{"user_id": {userA}, "limit": {"1"}}
+	for {
+		req := httptest.NewRequest(http.MethodGet, "/notifications?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var page NotificationListResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, n := range page.Notifications {
+			if seen[n.ID] {
+				t.Fatalf("notification %s returned twice", n.ID)
+			}
+			seen[n.ID] = true
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query.Set("cursor", *page.NextCursor)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 notifications, got %d", len(seen))
+	}
+}
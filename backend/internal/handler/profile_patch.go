@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+// MaxBioLength は自己紹介文の最大文字数
+const MaxBioLength = 500
+
+// アイコンに使える画像の拡張子
+var iconExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true}
+
+// OptionalString は JSON の「キーなし」「null」「値あり（空文字を含む）」を区別する
+type OptionalString struct {
+	Set   bool // キーがあった
+	Null  bool // null が送られた
+	Value string
+}
+
+func (o *OptionalString) UnmarshalJSON(b []byte) error {
+	o.Set = true
+	if bytes.Equal(b, []byte("null")) {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(b, &o.Value)
+}
+
+// PatchMyProfileRequest は送ったキーだけを更新する
+//   - username: 空文字・null は不可
+//   - bio: null で既定の自己紹介に戻す / "" で空にする
+type PatchMyProfileRequest struct {
+	Username OptionalString `json:"username"`
+	Bio      OptionalString `json:"bio"`
+}
+
+// PatchMyProfile は表示名と自己紹介文を部分更新する（アイコンは /me/icon）
+func (s *Server) PatchMyProfile(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var req PatchMyProfileRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	// だめな項目はまとめて返す
+	var invalid []apierror.FieldError
+	if req.Username.Set {
+		req.Username.Value = strings.TrimSpace(req.Username.Value)
+		switch {
+		case req.Username.Null || req.Username.Value == "":
+			invalid = append(invalid, apierror.FieldError{Field: "username", Reason: apierror.ReasonRequired})
+		case utf8.RuneCountInString(req.Username.Value) > MaxUsernameLength:
+			invalid = append(invalid, apierror.FieldError{Field: "username", Reason: apierror.ReasonTooLong, Param: strconv.Itoa(MaxUsernameLength)})
+		}
+	}
+	if req.Bio.Set && utf8.RuneCountInString(req.Bio.Value) > MaxBioLength {
+		invalid = append(invalid, apierror.FieldError{Field: "bio", Reason: apierror.ReasonTooLong, Param: strconv.Itoa(MaxBioLength)})
+	}
+	if len(invalid) > 0 {
+		apierror.Abort(c, apierror.Validation(invalid))
+		return
+	}
+
+	// 禁止語の検査（mask モードなら伏せ字にして保存する）
+	masked, ok := s.filterText(c,
+		textField{Name: "username", Value: &req.Username.Value},
+		textField{Name: "bio", Value: &req.Bio.Value},
+	)
+	if !ok {
+		return
+	}
+
+	var update repository.ProfileUpdate
+	if req.Username.Set {
+		update.Username = &req.Username.Value
+	}
+	if req.Bio.Set {
+		if req.Bio.Null {
+			update.ResetBio = true
+		} else {
+			update.Bio = &req.Bio.Value
+		}
+	}
+
+	if update.Username == nil && update.Bio == nil && !update.ResetBio {
+		apierror.Abort(c, apierror.New(apierror.CodeNothingToUpdate))
+		return
+	}
+
+	err := s.Users.UpdateProfile(context.Background(), userID, update)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	s.reportMaskedText(service.ReportTargetUser, userID, masked)
+
+	s.respondMyProfile(c, userID)
+}
+
+// IconForm は PUT /me/icon の multipart/form-data
+type IconForm struct {
+	Icon *multipart.FileHeader `form:"icon" binding:"required"`
+}
+
+// ReplaceMyIcon はアイコンを差し替え、以前のアイコン画像をストレージから消す
+func (s *Server) ReplaceMyIcon(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var form IconForm
+	if !bindForm(c, &form) {
+		return
+	}
+	fileHeader := form.Icon
+
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	if !iconExtensions[ext] {
+		apierror.Abort(c, apierror.Invalid("icon"))
+		return
+	}
+
+	// 毎回別のパスに保存して、CDN に古い画像が残らないようにする
+	objectPath := fmt.Sprintf("%s/%d%s", userID, time.Now().UnixNano(), ext)
+	if err := s.Storage.UploadToSupabase(c.Request.Context(), fileHeader, "icons", objectPath); err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	iconPath := "icons/" + objectPath
+	if err := s.setIconPath(c, userID, &iconPath); err != nil {
+		// DB を更新できなかったので、アップロードした画像を消しておく
+		if err := s.Storage.DeleteObject(context.Background(), iconPath); err != nil {
+			log.Printf("failed to delete orphan icon %s: %v", iconPath, err)
+		}
+		return
+	}
+
+	s.respondMyProfile(c, userID)
+}
+
+// DeleteMyIcon はアイコンを既定の画像に戻し、以前のアイコン画像をストレージから消す
+func (s *Server) DeleteMyIcon(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := s.setIconPath(c, userID, nil); err != nil {
+		return
+	}
+
+	s.respondMyProfile(c, userID)
+}
+
+// setIconPath は icon_path を更新し、以前のアイコン画像を消す（nil なら既定のアイコン）
+// 失敗したらレスポンスを書いてエラーを返す
+func (s *Server) setIconPath(c *gin.Context, userID string, iconPath *string) error {
+	ctx := context.Background()
+
+	oldPath, err := s.Users.SetIconPath(ctx, userID, iconPath)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return err
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return err
+	}
+
+	s.removeOldIcon(ctx, oldPath, iconPath)
+	return nil
+}
+
+// removeOldIcon は差し替え前のアイコン画像を消す（既定のアイコンは消さない）
+// 画像が残っても表示には影響しないので、失敗はログだけ残す
+func (s *Server) removeOldIcon(ctx context.Context, oldPath, newPath *string) {
+	if oldPath == nil || *oldPath == DefaultIconPath {
+		return
+	}
+	if newPath != nil && *newPath == *oldPath {
+		return
+	}
+	if err := s.Storage.DeleteObject(ctx, *oldPath); err != nil {
+		log.Printf("failed to delete old icon %s: %v", *oldPath, err)
+	}
+}
+
+func (s *Server) respondMyProfile(c *gin.Context, userID string) {
+	profile, err := s.loadMyProfile(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,135 @@
+// backend/internal/handler/profile_patch_test.go
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func patchProfile(t *testing.T, r http.Handler, userID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPatch, "/me?user_id="+userID, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestOptionalString(t *testing.T) {
+	var req PatchMyProfileRequest
+	if err := json.Unmarshal([]byte(`{"bio": null, "username": ""}`), &req); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if !req.Bio.Set || !req.Bio.Null {
+		t.Fatalf("expected bio null: %+v", req.Bio)
+	}
+	if !req.Username.Set || req.Username.Null || req.Username.Value != "" {
+		t.Fatalf("expected username empty string: %+v", req.Username)
+	}
+
+	req = PatchMyProfileRequest{}
+	_ = json.Unmarshal([]byte(`{}`), &req)
+	if req.Bio.Set || req.Username.Set {
+		t.Fatal("expected absent fields to be unset")
+	}
+}
+
+func TestPatchMyProfile_Semantics(t *testing.T) {
+	r := setupTestRouter(withPatchProfile)
+
+	userID := createTestUser(t)
+
+	// 表示名と自己紹介を設定
+	w := patchProfile(t, r, userID, `{"username": " きらり ", "bio": "水彩で描いています"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var profile UserProfileResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if profile.Username != "きらり" || profile.Bio != "水彩で描いています" {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+
+	// キーがなければ変わらない
+	w = patchProfile(t, r, userID, `{"username": "きらり2"}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if profile.Bio != "水彩で描いています" {
+		t.Fatalf("bio must not change when absent: %q", profile.Bio)
+	}
+
+	// 空文字なら空にする
+	w = patchProfile(t, r, userID, `{"bio": ""}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if profile.Bio != "" {
+		t.Fatalf("expected empty bio, got %q", profile.Bio)
+	}
+
+	// null なら既定に戻す
+	w = patchProfile(t, r, userID, `{"bio": null}`)
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if profile.Bio != DefaultBio {
+		t.Fatalf("expected default bio, got %q", profile.Bio)
+	}
+
+	// 表示名は空にできない
+	if w := patchProfile(t, r, userID, `{"username": null}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w := patchProfile(t, r, userID, `{}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for empty patch, got %d", w.Code)
+	}
+}
+
+func TestReplaceAndDeleteMyIcon(t *testing.T) {
+	r := setupTestRouter(withPatchProfile)
+
+	userID := createTestUser(t)
+
+	upload := func() UserProfileResponse {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("icon", "icon.png")
+		part.Write([]byte("dummy icon content"))
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPut, "/me/icon?user_id="+userID, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var profile UserProfileResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &profile)
+		return profile
+	}
+
+	first := upload()
+	second := upload()
+	if first.IconURL == second.IconURL {
+		t.Fatal("expected replaced icon to have a new URL")
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/me/icon?user_id="+userID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	user, err := testServer.Users.Get(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+	if user.IconPath != nil {
+		t.Fatalf("expected icon reset to default, got %s", *user.IconPath)
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+const (
+	defaultPortfolioLimit = 20
+	maxPortfolioLimit     = 50
+)
+
+// PublicProfileResponse は他のユーザーに見せるプロフィール（email は含めない）
+type PublicProfileResponse struct {
+	ID         string           `json:"id"`
+	Username   string           `json:"username"`
+	Handle     *string          `json:"handle"`
+	IconURL    string           `json:"icon_url"`
+	Bio        string           `json:"bio"`
+	WorkCount  int              `json:"work_count"`
+	Works      []MyWorkResponse `json:"works"`
+	NextCursor *string          `json:"next_cursor"` // 続きがなければ null
+}
+
+// GetPublicProfile はクリエイターの公開プロフィールと作品一覧を返す
+// user_id（見る人）とブロック関係にある、または停止中のユーザーは見つからない扱いにする
+// 作品は新しい順で、cursor には前のページの next_cursor を渡す
+func (s *Server) GetPublicProfile(c *gin.Context) {
+	targetID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+	viewerID, ok := optionalQueryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	limit := defaultPortfolioLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("limit"))
+			return
+		}
+		limit = min(n, maxPortfolioLimit)
+	}
+
+	cursor, ok := queryCursor(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+
+	// 存在しない・停止中・退会申請中はすべて 404
+	user, err := s.Users.Get(ctx, targetID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, err)
+		return
+	}
+	if err != nil || user.SuspendedAt != nil || user.DeletedAt != nil {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
+
+	if viewerID != "" && viewerID != targetID {
+		blocked, err := s.Blocks.IsBlocked(ctx, viewerID, targetID)
+		if err != nil {
+			apierror.Abort(c, err)
+			return
+		}
+		if blocked {
+			apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+			return
+		}
+	}
+
+	profile := PublicProfileResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Handle:   user.Handle,
+		Works:    []MyWorkResponse{},
+	}
+
+	if user.IconPath != nil {
+		profile.IconURL = s.Storage.PublicURL(*user.IconPath)
+	} else {
+		profile.IconURL = s.Storage.PublicURL(DefaultIconPath)
+	}
+	if user.Bio != nil {
+		profile.Bio = *user.Bio
+	} else {
+		profile.Bio = DefaultBio
+	}
+
+	profile.WorkCount, err = s.Works.CountVisible(ctx, targetID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	// 1 件多く取って続きがあるか判定する
+	works, err := s.Works.PageVisible(ctx, targetID, cursor, limit+1)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	for _, work := range works {
+		if len(profile.Works) == limit {
+			last := works[limit-1]
+			next := encodeCursor(last.CreatedAt, last.ID)
+			profile.NextCursor = &next
+			break
+		}
+
+		const DefaultImagePath = "images/default.png"
+
+		w := MyWorkResponse{
+			ID:        work.ID,
+			Title:     work.Title,
+			CreatedAt: work.CreatedAt.Format(time.RFC3339),
+		}
+		if work.ImagePath != nil {
+			w.ImageURL = s.Storage.PublicURL(*work.ImagePath)
+		} else {
+			w.ImageURL = s.Storage.PublicURL(DefaultImagePath)
+		}
+		if work.Description != nil {
+			w.Description = *work.Description
+		}
+
+		profile.Works = append(profile.Works, w)
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
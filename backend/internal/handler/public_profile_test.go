@@ -0,0 +1,122 @@
+// backend/internal/handler/public_profile_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPublicProfile(t *testing.T) {
+	r := setupTestRouter(withPublicProfile)
+
+	creator := createTestUser(t)
+	viewer := createTestUser(t)
+	works := []string{createTestWork(t, creator), createTestWork(t, creator), createTestWork(t, creator)}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+creator+"?limit=2&user_id="+viewer, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "email") {
+		t.Fatal("public profile must not include email")
+	}
+
+	var resp PublicProfileResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if resp.ID != creator || resp.WorkCount != 3 || resp.Bio != DefaultBio {
+		t.Fatalf("unexpected profile: %+v", resp)
+	}
+	if len(resp.Works) != 2 || resp.Works[0].ID != works[2] || resp.NextCursor == nil {
+		t.Fatalf("unexpected first page: %+v", resp.Works)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users/"+creator+"?limit=2&cursor="+*resp.NextCursor, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp = PublicProfileResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.Works) != 1 || resp.Works[0].ID != works[0] || resp.NextCursor != nil {
+		t.Fatalf("unexpected last page: %+v", resp.Works)
+	}
+}
+
+// 同じ時刻に投稿した作品もページの境目で抜けたり重複したりしない
+func TestGetPublicProfile_SameCreatedAt(t *testing.T) {
+	align := freezeCreatedAt(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := setupTestRouter(withPublicProfile)
+
+	creator := createTestUser(t)
+	for i := 0; i < 3; i++ {
+		_ = createTestWork(t, creator)
+	}
+	align("works", "user_id", creator)
+
+	seen := map[string]bool{}
+	path := "/users/" + creator + "?limit=1"
+	for {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var resp PublicProfileResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, work := range resp.Works {
+			if seen[work.ID] {
+				t.Fatalf("work %s returned twice", work.ID)
+			}
+			seen[work.ID] = true
+		}
+		if resp.NextCursor == nil {
+			break
+		}
+		path = "/users/" + creator + "?limit=1&cursor=" + *resp.NextCursor
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 works, got %d", len(seen))
+	}
+}
+
+func TestGetPublicProfile_BlockedOrSuspended(t *testing.T) {
+	r := setupTestRouter(withPublicProfile)
+	ctx := context.Background()
+
+	creator := createTestUser(t)
+	viewer := createTestUser(t)
+
+	// 作者が見る人をブロックしている
+	if err := testServer.Blocks.Block(ctx, creator, viewer); err != nil {
+		t.Fatalf("failed to block: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+creator+"?user_id="+viewer, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for blocked viewer, got %d", w.Code)
+	}
+
+	// 停止中のユーザーは誰からも見えない
+	if err := testServer.Users.Suspend(ctx, creator); err != nil {
+		t.Fatalf("failed to suspend: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/users/"+creator, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for suspended user, got %d", w.Code)
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+// MaxReportNoteLength は通報の補足の最大文字数
+const MaxReportNoteLength = 1000
+
+type CreateReportRequest struct {
+	UserID     string `json:"user_id" binding:"required,uuid"`
+	TargetType string `json:"target_type" binding:"required"` // review / work / user
+	TargetID   string `json:"target_id" binding:"required,uuid"`
+	Reason     string `json:"reason" binding:"required"`
+	Note       string `json:"note" binding:"max=1000"` // MaxReportNoteLength
+}
+
+// CreateReport はレビュー・作品・ユーザーを通報する
+func (s *Server) CreateReport(c *gin.Context) {
+	var req CreateReportRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+
+	var invalid []apierror.FieldError
+	if !service.IsReportTarget(req.TargetType) {
+		invalid = append(invalid, apierror.FieldError{Field: "target_type", Reason: apierror.ReasonInvalid})
+	}
+	if !service.IsReportReason(req.Reason) {
+		invalid = append(invalid, apierror.FieldError{Field: "reason", Reason: apierror.ReasonInvalid})
+	}
+	if len(invalid) > 0 {
+		apierror.Abort(c, apierror.Validation(invalid))
+		return
+	}
+
+	ctx := context.Background()
+
+	ownerID, err := service.TargetOwner(ctx, s.Reports, req.TargetType, req.TargetID)
+	if errors.Is(err, service.ErrTargetNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeTargetNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+	if ownerID == req.UserID {
+		apierror.Abort(c, apierror.New(apierror.CodeCannotTargetSelf))
+		return
+	}
+
+	reportID, err := s.Reports.Create(ctx, repository.Report{
+		ReporterID: &req.UserID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Reason:     req.Reason,
+		Note:       req.Note,
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		// 未処理の通報が既にある
+		apierror.Abort(c, apierror.New(apierror.CodeAlreadyReported))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "report created",
+		"report_id": reportID,
+	})
+}
@@ -0,0 +1,254 @@
+// backend/internal/handler/reports_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+func createTestAdmin(t *testing.T) string {
+	t.Helper()
+
+	adminID := createTestUser(t)
+	if err := testServer.Users.SetAdmin(context.Background(), adminID, true); err != nil {
+		t.Fatalf("failed to make admin: %v", err)
+	}
+	return adminID
+}
+
+func createReport(t *testing.T, r http.Handler, req CreateReportRequest) string {
+	t.Helper()
+
+	w := postJSON(r, http.MethodPost, "/reports", req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	cleanupRow(t, "reports", resp["report_id"])
+	return resp["report_id"]
+}
+
+func TestReport_HideWork(t *testing.T) {
+	r := setupTestRouter(withReports, withWorks)
+
+	admin := createTestAdmin(t)
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+
+	report := CreateReportRequest{
+		UserID:     userB,
+		TargetType: service.ReportTargetWork,
+		TargetID:   workA,
+		Reason:     service.ReportReasonSpam,
+	}
+	reportID := createReport(t, r, report)
+
+	// 未処理の通報は重複できない
+	if w := postJSON(r, http.MethodPost, "/reports", report); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+
+	// 一般ユーザーは管理画面を使えない
+	req := httptest.NewRequest(http.MethodGet, "/admin/reports?user_id="+userB, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/reports?user_id="+admin, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var reports []ReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &reports); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	found := false
+	for _, rep := range reports {
+		if rep.ID == reportID {
+			found = rep.Status == service.ReportStatusOpen && rep.ReportCount == 1
+		}
+	}
+	if !found {
+		t.Fatalf("open report %s not listed: %+v", reportID, reports)
+	}
+
+	// 知らない状態での絞り込みは 400
+	req = httptest.NewRequest(http.MethodGet, "/admin/reports?status=closed&user_id="+admin, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown status, got %d", w.Code)
+	}
+
+	if w := postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/triage", AdminRequest{UserID: admin}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 on triage, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 作品の通報にレビュー非表示は使えない
+	w = postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/resolve", ResolveReportRequest{
+		UserID: admin,
+		Action: service.ModerationHideReview,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	w = postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/resolve", ResolveReportRequest{
+		UserID: admin,
+		Action: service.ModerationHideWork,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 on resolve, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 非表示の作品はホームに出ない
+	req = httptest.NewRequest(http.MethodGet, "/works?user_id="+userB, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var works []WorkResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &works)
+	for _, work := range works {
+		if work.ID == workA {
+			t.Fatal("hidden work must not be returned")
+		}
+	}
+}
+
+func TestReport_SuspendReviewer(t *testing.T) {
+	r := setupTestRouter(withReports, withMatches, withReceivedReviews)
+
+	admin := createTestAdmin(t)
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "ひどいレビュー")
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	reportID := createReport(t, r, CreateReportRequest{
+		UserID:     userB,
+		TargetType: service.ReportTargetReview,
+		TargetID:   reviewID,
+		Reason:     service.ReportReasonHarassment,
+	})
+
+	// レビューの通報から投稿者を停止する
+	w := postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/resolve", ResolveReportRequest{
+		UserID: admin,
+		Action: service.ModerationSuspendUser,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, path := range []string{"/matches", "/reviews"} {
+		req := httptest.NewRequest(http.MethodGet, path+"?user_id="+userB, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var items []map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &items)
+		if len(items) != 0 {
+			t.Fatalf("%s: suspended user's content must be hidden, got %v", path, items)
+		}
+	}
+
+	// 閉じた通報は再度処理できない
+	w = postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/resolve", ResolveReportRequest{
+		UserID: admin,
+		Action: service.ModerationNone,
+	})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+}
+
+// 非表示にした作品のマッチとレビューは、一覧とレビュー状況のどちらにも出ない
+func TestReport_HiddenContentInMatchesAndStatus(t *testing.T) {
+	r := setupTestRouter(withReports, withMatches, withReviewStatus)
+
+	admin := createTestAdmin(t)
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userB, userA, workA, "ひどいレビュー")
+
+	for _, target := range []CreateReportRequest{
+		{UserID: userA, TargetType: service.ReportTargetReview, TargetID: reviewID, Reason: service.ReportReasonHarassment},
+		{UserID: userA, TargetType: service.ReportTargetWork, TargetID: workB, Reason: service.ReportReasonSpam},
+	} {
+		action := service.ModerationHideReview
+		if target.TargetType == service.ReportTargetWork {
+			action = service.ModerationHideWork
+		}
+		reportID := createReport(t, r, target)
+		w := postJSON(r, http.MethodPost, "/admin/reports/"+reportID+"/resolve", ResolveReportRequest{UserID: admin, Action: action})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 on resolve, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// 相手の作品が非表示なら、マッチ一覧に出さない
+	req := httptest.NewRequest(http.MethodGet, "/matches?user_id="+userA, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var matches []MatchResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &matches)
+	for _, m := range matches {
+		if m.MatchID == matchID {
+			t.Fatalf("match with hidden work must not be listed: %+v", m)
+		}
+	}
+
+	// 非表示のレビューは受け取っていない扱い（GET /reviews と揃える）
+	req = httptest.NewRequest(http.MethodGet, "/reviews/status?user_id="+userA, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var status ReviewStatusListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	for _, st := range status.Matches {
+		if st.MatchID == matchID && st.HasReceivedReview {
+			t.Fatalf("hidden review must not count as received: %+v", st)
+		}
+	}
+}
+
+func TestCreateReport_Validation(t *testing.T) {
+	r := setupTestRouter(withReports)
+
+	userA := createTestUser(t)
+
+	cases := []CreateReportRequest{
+		{UserID: userA, TargetType: "comment", TargetID: userA, Reason: service.ReportReasonSpam},
+		{UserID: userA, TargetType: service.ReportTargetUser, TargetID: userA, Reason: "boring"},
+		{UserID: userA, TargetType: service.ReportTargetUser, TargetID: userA, Reason: service.ReportReasonSpam},
+	}
+	for _, req := range cases {
+		if w := postJSON(r, http.MethodPost, "/reports", req); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %+v, got %d", req, w.Code)
+		}
+	}
+}
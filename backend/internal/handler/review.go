@@ -2,82 +2,169 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
 )
 
+// MaxReviewCommentLength はレビュー本文の最大文字数
+const MaxReviewCommentLength = 2000
+
+// recentCommentsLimit はコピペ検出で比べる過去のレビューの件数
+const recentCommentsLimit = 50
+
+// CreateReviewRequest は観点ごとの sections で送る
+// sections を送らない旧クライアントは comment だけでも投稿できる
 type CreateReviewRequest struct {
-	MatchID    string `json:"match_id"`
-	FromUserID string `json:"from_user_id"`
-	Comment    string `json:"comment"`
+	MatchID    string              `json:"match_id" binding:"required,uuid"`
+	FromUserID string              `json:"from_user_id" binding:"required,uuid"`
+	Comment    string              `json:"comment" binding:"max=2000"` // MaxReviewCommentLength
+	Sections   []guideline.Section `json:"sections" binding:"dive"`
 }
 
-func PostReview(c *gin.Context) {
+func (s *Server) PostReview(c *gin.Context) {
 	var req CreateReviewRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	ctx := context.Background()
+	masked, ok := s.filterReviewText(c, &req.Comment, req.Sections)
+	if !ok {
+		return
+	}
 
-	var (
-		user1ID string
-		user2ID string
-		work1ID string
-		work2ID string
-	)
+	comment, sections, ok := reviewBody(c, req.Comment, req.Sections)
+	if !ok {
+		return
+	}
+	req.Comment = comment
 
-	//match 情報を取得
-	err := db.Pool.QueryRow(ctx, `
-		SELECT user1_id, user2_id, work1_id, work2_id
-		FROM public.matches
-		WHERE id = $1
-	`, req.MatchID).Scan(&user1ID, &user2ID, &work1ID, &work2ID)
+	ctx := context.Background()
 
+	//match 情報を取得
+	match, err := s.Matches.Get(ctx, req.MatchID)
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.Invalid("match_id"))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match_id"})
+		apierror.Abort(c, err)
 		return
 	}
 
 	//from_user / to_user / work を決定
-	var toUserID string
-	var workID string
-
-	switch req.FromUserID {
-	case user1ID:
-		toUserID = user2ID
-		workID = work2ID
-	case user2ID:
-		toUserID = user1ID
-		workID = work1ID
-	default:
-		c.JSON(http.StatusForbidden, gin.H{"error": "user not in this match"})
+	toUserID, workID, ok := match.Partner(req.FromUserID)
+	if !ok {
+		apierror.Abort(c, apierror.New(apierror.CodeNotMatchMember))
+		return
+	}
+
+	// 送信済みなら編集（PATCH /reviews/:id）を案内する
+	existingID, err := s.Reviews.FindID(ctx, req.MatchID, req.FromUserID)
+	if err == nil {
+		apierror.Abort(c, apierror.New(apierror.CodeAlreadyReviewed).WithDetails(gin.H{
+			"review_id": existingID,
+		}))
 		return
 	}
 
+	// 品質チェック（空・連打・定型文・過去レビューのコピペ）
+	previous, err := s.Reviews.RecentComments(ctx, req.FromUserID, "", recentCommentsLimit)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	issues := quality.CheckReview(req.Comment, sections, previous)
+	if len(issues) > 0 && s.QualityMode == quality.ModeReject {
+		apierror.Abort(c, apierror.New(apierror.CodeLowQualityReview).WithDetails(gin.H{
+			"issues": issues,
+		}))
+		return
+	}
+	flags := quality.Codes(issues)
+
 	//review を保存
-	_, err = db.Pool.Exec(ctx, `
-		INSERT INTO public.reviews (
-			match_id,
-			from_user_id,
-			to_user_id,
-			work_id,
-			comment
-		)
-		VALUES ($1, $2, $3, $4, $5)
-	`, req.MatchID, req.FromUserID, toUserID, workID, req.Comment)
+	reviewID, err := s.Reviews.Create(ctx, repository.Review{
+		MatchID:      req.MatchID,
+		FromUserID:   req.FromUserID,
+		ToUserID:     toUserID,
+		WorkID:       workID,
+		Comment:      req.Comment,
+		Sections:     sections,
+		QualityFlags: flags,
+	})
 
+	// 同時に送られたときは一意制約で弾かれる
+	if errors.Is(err, repository.ErrConflict) {
+		apierror.Abort(c, apierror.New(apierror.CodeAlreadyReviewed))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusConflict, gin.H{
-			"error": "already reviewed or failed to create review",
-		})
+		apierror.Abort(c, err)
 		return
 	}
 
+	s.reportMaskedText(service.ReportTargetReview, reviewID, masked)
+
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "review created",
+		"message":       "review created",
+		"quality_flags": flags,
+	})
+
+	// リアルタイム通知は非同期
+	s.Tasks.Go("notify review posted", func(ctx context.Context) {
+		s.Notifier.NotifyReviewPosted(ctx, reviewID, req.MatchID, req.FromUserID, toUserID)
 	})
 }
+
+// reviewBody は観点ごとのレビューを検証し、comment を組み立てる
+// 不正な場合は 400 を返して ok=false
+// 空のレビューで Give-to-Get を解除できないよう、品質チェックのモードにかかわらず本文が空なら受け付けない
+func reviewBody(c *gin.Context, comment string, input []guideline.Section) (string, []guideline.Section, bool) {
+	sections := []guideline.Section{}
+	if len(input) == 0 {
+		if strings.TrimSpace(comment) == "" {
+			apierror.Abort(c, apierror.Required("comment", "sections"))
+			return "", nil, false
+		}
+		return comment, sections, true
+	}
+
+	sections = guideline.Normalize(input)
+
+	var ve *guideline.ValidationError
+	if err := guideline.Validate(sections); errors.As(err, &ve) {
+		apierror.Abort(c, apierror.New(apierror.CodeInvalidSections).WithDetails(gin.H{
+			"sections": ve.Errors,
+		}))
+		return "", nil, false
+	}
+
+	if strings.TrimSpace(comment) == "" {
+		comment = guideline.Compose(sections)
+		// 観点ごとには上限内でも、見出しを付けてまとめると comment の上限を超えることがある
+		if utf8.RuneCountInString(comment) > MaxReviewCommentLength {
+			apierror.Abort(c, apierror.TooLong("sections"))
+			return "", nil, false
+		}
+	}
+	return comment, sections, true
+}
+
+// filterReviewText は comment と観点ごとの本文に禁止語の検査をかける
+func (s *Server) filterReviewText(c *gin.Context, comment *string, sections []guideline.Section) ([]string, bool) {
+	fields := []textField{{Name: "comment", Value: comment}}
+	for i := range sections {
+		fields = append(fields, textField{Name: "sections." + sections[i].Aspect, Value: &sections[i].Body})
+	}
+	return s.filterText(c, fields...)
+}
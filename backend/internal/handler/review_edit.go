@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+// ReviewEditWindow は投稿後にレビューを編集できる期間
+// 期間内でも、相手が読めるようになった（ロックが外れた）レビューは編集できない
+const ReviewEditWindow = 24 * time.Hour
+
+type UpdateReviewRequest struct {
+	UserID   string              `json:"user_id" binding:"required,uuid"`
+	Comment  string              `json:"comment" binding:"max=2000"` // MaxReviewCommentLength
+	Sections []guideline.Section `json:"sections" binding:"dive"`
+}
+
+// UpdateReview は送信したレビューを編集する（編集前の本文は review_revisions に残す）
+func (s *Server) UpdateReview(c *gin.Context) {
+	reviewID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req UpdateReviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	masked, ok := s.filterReviewText(c, &req.Comment, req.Sections)
+	if !ok {
+		return
+	}
+
+	comment, sections, ok := reviewBody(c, req.Comment, req.Sections)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+
+	previous, err := s.Reviews.RecentComments(ctx, req.UserID, reviewID, recentCommentsLimit)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	issues := quality.CheckReview(comment, sections, previous)
+	if len(issues) > 0 && s.QualityMode == quality.ModeReject {
+		apierror.Abort(c, apierror.New(apierror.CodeLowQualityReview).WithDetails(gin.H{
+			"issues": issues,
+		}))
+		return
+	}
+	flags := quality.Codes(issues)
+
+	err = s.Reviews.Edit(ctx, reviewID, func(r repository.Review) error {
+		if r.FromUserID != req.UserID {
+			return apierror.New(apierror.CodeNotYourReview)
+		}
+		if r.Unlocked {
+			return apierror.New(apierror.CodeReviewAlreadyVisible)
+		}
+		if time.Since(r.CreatedAt) > ReviewEditWindow {
+			return apierror.New(apierror.CodeReviewEditExpired)
+		}
+		return nil
+	}, repository.ReviewEdit{
+		Comment:      comment,
+		Sections:     sections,
+		QualityFlags: flags,
+	})
+
+	if errors.Is(err, repository.ErrNotFound) {
+		apierror.Abort(c, apierror.New(apierror.CodeReviewNotFound))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	s.reportMaskedText(service.ReportTargetReview, reviewID, masked)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "review updated",
+		"quality_flags": flags,
+	})
+}
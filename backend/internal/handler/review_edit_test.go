@@ -0,0 +1,138 @@
+// backend/internal/handler/review_edit_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+)
+
+func TestUpdateReview_SavesRevision(t *testing.T) {
+	r := setupTestRouter(withReviewEdit, withReceivedReviews)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "光の入り方がきれいでした（誤字あり")
+
+	w := postJSON(r, http.MethodPatch, "/reviews/"+reviewID, UpdateReviewRequest{
+		UserID:  userA,
+		Comment: "光の入り方がきれいで、窓辺の静けさが伝わってきました",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 編集前の本文が履歴に残る
+	revisions, err := testServer.Reviews.Revisions(context.Background(), reviewID)
+	if err != nil || len(revisions) != 1 {
+		t.Fatalf("revision not saved: %v %+v", err, revisions)
+	}
+	if revisions[0].Comment != "光の入り方がきれいでした（誤字あり" {
+		t.Fatalf("unexpected revision comment: %s", revisions[0].Comment)
+	}
+
+	// B が A にレビューを返すと、A のレビューは edited 付きで読める
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews?user_id="+userB, nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var resp []ReceivedReviewResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp) != 1 || !resp[0].Edited {
+		t.Fatalf("expected edited review, got %+v", resp)
+	}
+}
+
+func TestUpdateReview_Forbidden(t *testing.T) {
+	r := setupTestRouter(withReviewEdit)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "最初のレビュー本文です")
+	body := UpdateReviewRequest{
+		UserID:  userA,
+		Comment: "差し替えたレビュー本文です。色の重ね方が丁寧",
+	}
+
+	// 他人のレビューは編集できない
+	other := body
+	other.UserID = userB
+	if w := postJSON(r, http.MethodPatch, "/reviews/"+reviewID, other); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for other user, got %d", w.Code)
+	}
+
+	// 相手が読めるようになったら編集できない
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+	if w := postJSON(r, http.MethodPatch, "/reviews/"+reviewID, body); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 after unlock, got %d", w.Code)
+	}
+}
+
+// 品質チェックが flag でも、本文を空にする編集はできない
+func TestUpdateReview_RejectsEmpty(t *testing.T) {
+	r := setupTestRouter(withReviewEdit)
+
+	testServer.QualityMode = quality.ModeFlag
+	t.Cleanup(func() { testServer.QualityMode = quality.ModeReject })
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "最初のレビュー本文です")
+
+	for _, comment := range []string{"", "   "} {
+		w := postJSON(r, http.MethodPatch, "/reviews/"+reviewID, UpdateReviewRequest{UserID: userA, Comment: comment})
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %q, got %d: %s", comment, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestPostReview_AlreadyReviewed(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "最初のレビュー本文です")
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "もう一度送ろうとしたレビュー本文です",
+	})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Code    apierror.Code     `json:"code"`
+		Details map[string]string `json:"details"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Code != apierror.CodeAlreadyReviewed || resp.Details["review_id"] != reviewID {
+		t.Fatalf("expected existing review_id %s, got %v", reviewID, resp)
+	}
+}
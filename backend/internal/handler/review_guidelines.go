@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+type RatingScale struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type ReviewGuidelinesResponse struct {
+	Aspects []guideline.Aspect `json:"aspects"`
+	Rating  RatingScale        `json:"rating"`
+}
+
+// GetReviewGuidelines はレビュー画面に表示する観点の定義を返す
+func (s *Server) GetReviewGuidelines(c *gin.Context) {
+	c.JSON(http.StatusOK, ReviewGuidelinesResponse{
+		Aspects: guideline.Aspects,
+		Rating:  RatingScale{Min: guideline.MinRating, Max: guideline.MaxRating},
+	})
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+// レビューへのリアクション（受信者が投稿者に返す）
+const (
+	ReactionThanks   = "thanks"
+	ReactionHelpful  = "helpful"
+	ReactionInspired = "inspired"
+)
+
+// Reactions は使えるリアクションの一覧
+var Reactions = []string{ReactionThanks, ReactionHelpful, ReactionInspired}
+
+// IsReaction はリアクションとして有効か判定する
+func IsReaction(r string) bool {
+	for _, v := range Reactions {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+type MarkReviewReadRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+type ReactToReviewRequest struct {
+	UserID   string `json:"user_id" binding:"required,uuid"`
+	Reaction string `json:"reaction" binding:"required"`
+}
+
+// receivedReview は userID が受け取ったレビューを確認し、マッチと投稿者の ID を返す
+// ロック中（まだ読めない）のレビューには既読もリアクションも付けられない
+// 退会したユーザーのレビューではマッチと投稿者の ID は空になる
+func (s *Server) receivedReview(ctx context.Context, reviewID, userID string) (string, string, error) {
+	r, err := s.Reviews.Get(ctx, reviewID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", "", apierror.New(apierror.CodeReviewNotFound)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if r.ToUserID != userID {
+		return "", "", apierror.New(apierror.CodeNotYourReview)
+	}
+	if !r.Unlocked {
+		return "", "", apierror.New(apierror.CodeReviewLocked)
+	}
+
+	return r.MatchID, r.FromUserID, nil
+}
+
+// MarkReviewRead は受け取ったレビューを既読にする（投稿者に届いたことが伝わる）
+func (s *Server) MarkReviewRead(c *gin.Context) {
+	reviewID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req MarkReviewReadRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx := context.Background()
+
+	matchID, reviewerID, err := s.receivedReview(ctx, reviewID, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	// 最初に読んだ日時だけを残す
+	marked, err := s.Reviews.MarkRead(ctx, reviewID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	if marked && reviewerID != "" {
+		s.Tasks.Go("notify review read", func(ctx context.Context) {
+			s.Notifier.NotifyReviewRead(ctx, reviewID, matchID, req.UserID, reviewerID)
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "review marked as read"})
+}
+
+// ReactToReview は受け取ったレビューにリアクションする（同じ種類は 1 回まで）
+func (s *Server) ReactToReview(c *gin.Context) {
+	reviewID, ok := paramUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ReactToReviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if !IsReaction(req.Reaction) {
+		apierror.Abort(c, apierror.Invalid("reaction"))
+		return
+	}
+
+	ctx := context.Background()
+
+	matchID, reviewerID, err := s.receivedReview(ctx, reviewID, req.UserID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	// リアクションしたなら読んだとみなす
+	added, err := s.Reviews.React(ctx, reviewID, req.Reaction)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	reactions, err := s.Reviews.Reactions(ctx, reviewID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	if added && reviewerID != "" {
+		s.Tasks.Go("notify review reacted", func(ctx context.Context) {
+			s.Notifier.NotifyReviewReacted(ctx, reviewID, matchID, req.UserID, reviewerID, req.Reaction)
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reactions": reactions})
+}
@@ -0,0 +1,102 @@
+// backend/internal/handler/review_reactions_test.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getSentReviews(t *testing.T, r http.Handler, userID string) []SentReviewResponse {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/sent?user_id="+userID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp SentReviewListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return resp.Reviews
+}
+
+func TestReviewReadAndReactions(t *testing.T) {
+	r := setupTestRouter(withReviewReactions)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "光の入り方がきれい")
+
+	// B はまだレビューしていないのでロック中
+	sent := getSentReviews(t, r, userA)
+	if len(sent) != 1 || sent[0].Status != SentReviewLocked {
+		t.Fatalf("expected locked, got %+v", sent)
+	}
+	w := postJSON(r, http.MethodPost, "/reviews/"+reviewID+"/read", MarkReviewReadRequest{UserID: userB})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for locked review, got %d", w.Code)
+	}
+
+	// B がレビューを返すと届いた状態になる
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+	if sent = getSentReviews(t, r, userA); sent[0].Status != SentReviewDelivered {
+		t.Fatalf("expected delivered, got %s", sent[0].Status)
+	}
+
+	// 投稿者本人は既読にできない
+	w = postJSON(r, http.MethodPost, "/reviews/"+reviewID+"/read", MarkReviewReadRequest{UserID: userA})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for reviewer, got %d", w.Code)
+	}
+
+	w = postJSON(r, http.MethodPost, "/reviews/"+reviewID+"/read", MarkReviewReadRequest{UserID: userB})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = postJSON(r, http.MethodPost, "/reviews/"+reviewID+"/reactions", ReactToReviewRequest{
+		UserID:   userB,
+		Reaction: ReactionThanks,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	sent = getSentReviews(t, r, userA)
+	if sent[0].Status != SentReviewRead || sent[0].ReadAt == nil {
+		t.Fatalf("expected read, got %+v", sent[0])
+	}
+	if len(sent[0].Reactions) != 1 || sent[0].Reactions[0] != ReactionThanks {
+		t.Fatalf("unexpected reactions: %v", sent[0].Reactions)
+	}
+}
+
+func TestReactToReview_InvalidReaction(t *testing.T) {
+	r := setupTestRouter(withReviewReactions)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	reviewID := createTestReview(t, matchID, userA, userB, workB, "光の入り方がきれい")
+
+	w := postJSON(r, http.MethodPost, "/reviews/"+reviewID+"/reactions", ReactToReviewRequest{
+		UserID:   userB,
+		Reaction: "angry",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+)
+
+// 受信レビューを読むためにユーザーがすること
+const (
+	UnlockActionNone          = "none"            // 読める（または読むものがない）
+	UnlockActionPostReview    = "post_review"     // 相手にレビューを送る
+	UnlockActionWaitForReview = "wait_for_review" // 自分は送った。相手からのレビュー待ち
+)
+
+type ReviewStatusResponse struct {
+	MatchID           string  `json:"match_id"`
+	UserID            string  `json:"user_id"`
+	Username          string  `json:"username"`
+	IconURL           string  `json:"icon_url"`
+	HasSentReview     bool    `json:"has_sent_review"`
+	HasReceivedReview bool    `json:"has_received_review"`
+	ReceivedReviewID  *string `json:"received_review_id"`
+	IsLocked          bool    `json:"is_locked"`
+	UnlockAction      string  `json:"unlock_action"`
+}
+
+type ReviewStatusListResponse struct {
+	Matches     []ReviewStatusResponse `json:"matches"`
+	LockedCount int                    `json:"locked_count"`
+}
+
+// GetReviewStatus はマッチごとにレビューの送受信状況とロック状態を返す
+// 「レビューが来ていない」と「来ているがロック中」をアプリで区別するために使う
+func (s *Server) GetReviewStatus(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	statuses, err := s.Reviews.Statuses(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	resp := ReviewStatusListResponse{Matches: []ReviewStatusResponse{}}
+
+	for _, st := range statuses {
+		status := ReviewStatusResponse{
+			MatchID:          st.MatchID,
+			UserID:           st.UserID,
+			Username:         st.Username,
+			HasSentReview:    st.HasSentReview,
+			ReceivedReviewID: st.ReceivedReviewID,
+		}
+
+		if st.IconPath != nil {
+			status.IconURL = s.Storage.PublicURL(*st.IconPath)
+		} else {
+			status.IconURL = s.Storage.PublicURL(DefaultIconPath)
+		}
+
+		status.HasReceivedReview = status.ReceivedReviewID != nil
+		status.IsLocked, status.UnlockAction = unlockState(status.HasSentReview, status.HasReceivedReview)
+		if status.IsLocked {
+			resp.LockedCount++
+		}
+
+		resp.Matches = append(resp.Matches, status)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// unlockState は送受信状況からロック状態と次にすべきことを決める
+func unlockState(hasSent, hasReceived bool) (bool, string) {
+	switch {
+	case hasReceived && !hasSent:
+		return true, UnlockActionPostReview
+	case hasReceived && hasSent:
+		return false, UnlockActionNone
+	case hasSent:
+		return false, UnlockActionWaitForReview
+	default:
+		return false, UnlockActionPostReview
+	}
+}
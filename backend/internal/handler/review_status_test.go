@@ -0,0 +1,78 @@
+// backend/internal/handler/review_status_test.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getReviewStatus(t *testing.T, r http.Handler, userID, matchID string) ReviewStatusResponse {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/status?user_id="+userID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp ReviewStatusListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	for _, s := range resp.Matches {
+		if s.MatchID == matchID {
+			return s
+		}
+	}
+	t.Fatalf("match %s not found in status", matchID)
+	return ReviewStatusResponse{}
+}
+
+func TestGetReviewStatus_Transitions(t *testing.T) {
+	r := setupTestRouter(withReviewStatus)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	// まだ誰もレビューしていない
+	s := getReviewStatus(t, r, userA, matchID)
+	if s.HasReceivedReview || s.IsLocked || s.UnlockAction != UnlockActionPostReview {
+		t.Fatalf("unexpected initial status: %+v", s)
+	}
+	if s.UserID != userB {
+		t.Fatalf("expected counterpart %s, got %s", userB, s.UserID)
+	}
+
+	// B → A のレビューが届く（A から見るとロック中）
+	reviewID := createTestReview(t, matchID, userB, userA, workA, "nice work!")
+
+	s = getReviewStatus(t, r, userA, matchID)
+	if !s.HasReceivedReview || !s.IsLocked || s.UnlockAction != UnlockActionPostReview {
+		t.Fatalf("expected locked status: %+v", s)
+	}
+	if s.ReceivedReviewID == nil || *s.ReceivedReviewID != reviewID {
+		t.Fatalf("unexpected received_review_id: %v", s.ReceivedReviewID)
+	}
+
+	// B から見ると A のレビュー待ち
+	s = getReviewStatus(t, r, userB, matchID)
+	if s.HasReceivedReview || s.IsLocked || s.UnlockAction != UnlockActionWaitForReview {
+		t.Fatalf("expected waiting status: %+v", s)
+	}
+
+	// A → B のレビューで A のロックが外れる
+	_ = createTestReview(t, matchID, userA, userB, workB, "thanks!")
+
+	s = getReviewStatus(t, r, userA, matchID)
+	if !s.HasReceivedReview || s.IsLocked || s.UnlockAction != UnlockActionNone {
+		t.Fatalf("expected unlocked status: %+v", s)
+	}
+}
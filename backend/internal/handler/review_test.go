@@ -5,11 +5,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 )
 
 /*
@@ -28,34 +33,13 @@ func TestPostReviewSuccess(t *testing.T) {
 	workB := createTestWork(t, userB)
 
 	// --- match 作成 ---
-	var matchID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		INSERT INTO public.matches (user1_id, user2_id, work1_id, work2_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id
-		`,
-		userA, userB, workA, workB,
-	).Scan(&matchID)
-
-	if err != nil {
-		t.Fatalf("failed to create match: %v", err)
-	}
-
-	t.Cleanup(func() {
-		db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.matches WHERE id=$1",
-			matchID,
-		)
-	})
+	matchID := createTestMatch(t, userA, userB, workA, workB)
 
 	// --- review API ---
 	body := CreateReviewRequest{
 		MatchID:    matchID,
 		FromUserID: userA,
-		Comment:    "great work!",
+		Comment:    "夕焼けのグラデーションが丁寧で、空気の温度まで伝わってきました",
 	}
 
 	b, _ := json.Marshal(body)
@@ -70,22 +54,293 @@ func TestPostReviewSuccess(t *testing.T) {
 		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// --- DB確認 ---
-	var count int
-	err = db.Pool.QueryRow(
-		context.Background(),
-		`
-		SELECT COUNT(*) FROM public.reviews
-		WHERE match_id=$1 AND from_user_id=$2
-		`,
-		matchID, userA,
-	).Scan(&count)
+	// --- 保存されているか ---
+	if _, err := testServer.Reviews.FindID(context.Background(), matchID, userA); err != nil {
+		t.Fatalf("review not saved: %v", err)
+	}
+}
+
+/*
+========================
+正常系：観点ごとのレビュー
+========================
+*/
+func TestPostReviewWithSections(t *testing.T) {
+	r := setupTestRouter(withReview, withReceivedReviews)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	rating := 4
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Sections: []guideline.Section{
+			{Aspect: guideline.AspectObservation, Body: "光の入り方がきれい", Rating: &rating},
+			{Aspect: guideline.AspectFirstImpression, Body: "透明感がある"},
+		},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// B → A のレビューで A の受信レビューが開く
+	_ = createTestReview(t, matchID, userB, userA, workA, "thanks!")
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews?user_id="+userB, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp []ReceivedReviewResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp) != 1 {
+		t.Fatalf("expected 1 review, got %d", len(resp))
+	}
+
+	got := resp[0]
+	if len(got.Sections) != 2 || got.Sections[0].Aspect != guideline.AspectFirstImpression {
+		t.Fatalf("unexpected sections: %+v", got.Sections)
+	}
+	if got.Sections[1].Rating == nil || *got.Sections[1].Rating != 4 {
+		t.Fatalf("unexpected rating: %+v", got.Sections[1])
+	}
+	if got.Comment == "" {
+		t.Fatal("expected composed comment for legacy clients")
+	}
+}
+
+/*
+========================
+異常系：必須の観点が足りない
+========================
+*/
+func TestPostReviewMissingRequiredSection(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Sections: []guideline.Section{
+			{Aspect: guideline.AspectFirstImpression, Body: "透明感がある"},
+		},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+/*
+========================
+異常系：観点の本文が長すぎる
+========================
+*/
+func TestPostReviewSectionsTooLong(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	long := strings.Repeat("光", guideline.MaxBodyLength+1)
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Sections: []guideline.Section{
+			{Aspect: guideline.AspectFirstImpression, Body: "透明感がある"},
+			{Aspect: guideline.AspectObservation, Body: long},
+		},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a long section, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// 1 つずつは上限内でも、まとめた comment が上限を超えるなら弾く
+	body := strings.Repeat("光", guideline.MaxBodyLength-100)
+	w = postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Sections: []guideline.Section{
+			{Aspect: guideline.AspectFirstImpression, Body: body},
+			{Aspect: guideline.AspectObservation, Body: body},
+			{Aspect: guideline.AspectInterpretation, Body: body},
+		},
+	})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), string(apierror.CodeTooLong)) {
+		t.Fatalf("expected too_long for the composed comment, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetReviewGuidelines(t *testing.T) {
+	r := setupTestRouter(withReviewGuidelines)
 
+	req := httptest.NewRequest(http.MethodGet, "/review-guidelines", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var resp ReviewGuidelinesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp.Aspects) != len(guideline.Aspects) {
+		t.Fatalf("expected %d aspects, got %d", len(guideline.Aspects), len(resp.Aspects))
+	}
+	if resp.Rating.Min != guideline.MinRating || resp.Rating.Max != guideline.MaxRating {
+		t.Fatalf("unexpected rating scale: %+v", resp.Rating)
+	}
+}
+
+/*
+========================
+異常系：低品質なレビュー
+========================
+*/
+func TestPostReviewRejectsLowQuality(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "いいね！！！！！！",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Error   string        `json:"error"`
+		Code    apierror.Code `json:"code"`
+		Details struct {
+			Issues []quality.Issue `json:"issues"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if resp.Code != apierror.CodeLowQualityReview {
+		t.Fatalf("unexpected code: %s", resp.Code)
+	}
+	if len(resp.Details.Issues) == 0 || resp.Details.Issues[0].Code != quality.CodeTooShort {
+		t.Fatalf("unexpected issues: %+v", resp.Details.Issues)
+	}
+}
+
+func TestPostReviewRejectsCopyPaste(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	userC := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	workC := createTestWork(t, userC)
+	matchAB := createTestMatch(t, userA, userB, workA, workB)
+	matchAC := createTestMatch(t, userA, userC, workA, workC)
+
+	comment := "夕焼けのグラデーションが丁寧で、空気の温度まで伝わってきました"
+	_ = createTestReview(t, matchAB, userA, userB, workB, comment)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchAC,
+		FromUserID: userA,
+		Comment:    comment,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPostReviewFlagMode(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	testServer.QualityMode = quality.ModeFlag
+	t.Cleanup(func() { testServer.QualityMode = quality.ModeReject })
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "Great work!! Really nice.",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	ctx := context.Background()
+	reviewID, err := testServer.Reviews.FindID(ctx, matchID, userA)
 	if err != nil {
-		t.Fatalf("db check failed: %v", err)
+		t.Fatalf("review not saved: %v", err)
+	}
+	review, err := testServer.Reviews.Get(ctx, reviewID)
+	if err != nil {
+		t.Fatalf("failed to load review: %v", err)
+	}
+	if len(review.QualityFlags) == 0 || review.QualityFlags[0] != quality.CodeBoilerplate {
+		t.Fatalf("expected boilerplate flag, got %v", review.QualityFlags)
+	}
+}
+
+// 品質チェックが flag でも、空のレビューで相手のレビューを解除させない
+func TestPostReviewRejectsEmpty(t *testing.T) {
+	r := setupTestRouter(withReview)
+	t.Cleanup(func() { testServer.QualityMode = quality.ModeReject })
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	for _, mode := range []quality.Mode{quality.ModeReject, quality.ModeFlag} {
+		testServer.QualityMode = mode
+		for _, comment := range []string{"", "   "} {
+			w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+				MatchID:    matchID,
+				FromUserID: userA,
+				Comment:    comment,
+			})
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("%s: expected 400 for %q, got %d: %s", mode, comment, w.Code, w.Body.String())
+			}
+
+			var resp struct {
+				Code apierror.Code `json:"code"`
+			}
+			_ = json.Unmarshal(w.Body.Bytes(), &resp)
+			if resp.Code != apierror.CodeMissingParameter {
+				t.Fatalf("%s: unexpected code: %s", mode, resp.Code)
+			}
+		}
 	}
 
-	if count != 1 {
-		t.Fatalf("expected 1 review, got %d", count)
+	if _, err := testServer.Reviews.FindID(context.Background(), matchID, userA); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("empty review must not be saved, got %v", err)
 	}
 }
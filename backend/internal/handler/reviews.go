@@ -6,106 +6,81 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
 )
 
 type ReceivedReviewResponse struct {
-	ReviewID     string `json:"review_id"`
-	MatchID      string `json:"match_id"`
-	UserID       string `json:"user_id"`
-	Username     string `json:"username"`
-	IconURL      string `json:"icon_url"`
-	WorkID       string `json:"work_id"`
-	WorkImageURL string `json:"work_image_url"`
-	WorkTitle    string `json:"work_title"`
-	Comment      string `json:"comment"`
-	CreatedAt    string `json:"created_at"`
+	ReviewID     string              `json:"review_id"`
+	MatchID      string              `json:"match_id"`
+	UserID       string              `json:"user_id"`
+	Username     string              `json:"username"`
+	IconURL      string              `json:"icon_url"`
+	WorkID       string              `json:"work_id"`
+	WorkImageURL string              `json:"work_image_url"`
+	WorkTitle    string              `json:"work_title"`
+	Comment      string              `json:"comment"`
+	Sections     []guideline.Section `json:"sections"`  // comment だけの旧形式のレビューでは空
+	IsLocked     bool                `json:"is_locked"` // include_locked=true のときだけ true になりうる
+	Edited       bool                `json:"edited"`
+	IsAnonymous  bool                `json:"is_anonymous"` // 退会したユーザーのレビュー（user_id と match_id、work_id は空）
+	CreatedAt    string              `json:"created_at"`
 }
 
-func GetReceivedReviews(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+func (s *Server) GetReceivedReviews(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
 	ctx := context.Background()
 
-	rows, err := db.Pool.Query(ctx, `
-		SELECT
-		  r.id AS review_id,
-		  r.match_id,
-		  u.id AS user_id,
-		  u.username,
-		  u.icon_path,
-		  w.id AS work_id,
-		  w.image_path AS work_image_path,
-		  w.title,
-		  r.comment,
-		  r.created_at
-		FROM public.reviews r
-		JOIN public.users u
-		  ON u.id = r.from_user_id
-		JOIN public.works w
-		  ON w.id = r.work_id
-		WHERE r.to_user_id = $1
-		  AND EXISTS (
-		    SELECT 1
-		    FROM public.reviews my
-		    WHERE my.match_id = r.match_id
-		      AND my.from_user_id = $1
-		      AND my.to_user_id = r.from_user_id
-		  )
-		ORDER BY r.created_at DESC
-	`, userID)
+	// include_locked=true ならロック中のレビューも本文なしで返す（ティーザー表示用）
+	includeLocked := c.Query("include_locked") == "true"
 
+	list, err := s.Reviews.Received(ctx, userID, includeLocked)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	reviews := []ReceivedReviewResponse{}
 
-	for rows.Next() {
-		var r ReceivedReviewResponse
-		var iconPath, workPath *string
-		var createdAt time.Time
-
-		if err := rows.Scan(
-			&r.ReviewID,
-			&r.MatchID,
-			&r.UserID,
-			&r.Username,
-			&iconPath,
-			&r.WorkID,
-			&workPath,
-			&r.WorkTitle,
-			&r.Comment,
-			&createdAt,
-		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	for _, review := range list {
+		r := ReceivedReviewResponse{
+			ReviewID:    review.ID,
+			MatchID:     review.MatchID,
+			UserID:      review.FromUserID,
+			Username:    review.FromUsername,
+			WorkID:      review.WorkID,
+			WorkTitle:   review.WorkTitle,
+			Comment:     review.Comment,
+			Sections:    review.Sections,
+			IsLocked:    review.Locked,
+			Edited:      review.Edited,
+			IsAnonymous: review.Anonymous,
+			CreatedAt:   review.CreatedAt.Format(time.RFC3339),
+		}
+		// 退会したユーザーの匿名化されたレビュー
+		if review.FromUserID == "" {
+			r.Username = AnonymousUsername
 		}
 
 		const DefaultIconPath = "icons/default.png"
 		const DefaultWorkImagePath = "images/default.png"
 
-		if iconPath != nil {
-			r.IconURL = lib.BuildPublicURL(*iconPath)
+		if review.FromIconPath != nil {
+			r.IconURL = s.Storage.PublicURL(*review.FromIconPath)
 		} else {
-			r.IconURL = lib.BuildPublicURL(DefaultIconPath)
+			r.IconURL = s.Storage.PublicURL(DefaultIconPath)
 		}
 
-		if workPath != nil {
-			r.WorkImageURL = lib.BuildPublicURL(*workPath)
+		if review.WorkImagePath != nil {
+			r.WorkImageURL = s.Storage.PublicURL(*review.WorkImagePath)
 		} else {
-			r.WorkImageURL = lib.BuildPublicURL(DefaultWorkImagePath)
+			r.WorkImageURL = s.Storage.PublicURL(DefaultWorkImagePath)
 		}
 
-		r.CreatedAt = createdAt.Format(time.RFC3339)
-
 		reviews = append(reviews, r)
 	}
 
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+// 送ったレビューの届き具合
+const (
+	SentReviewLocked    = "locked"    // 相手がまだレビューしていないので読めない
+	SentReviewDelivered = "delivered" // 相手は読めるが、まだ読んでいない
+	SentReviewRead      = "read"      // 相手が読んだ
+)
+
+const (
+	defaultSentReviewLimit = 20
+	maxSentReviewLimit     = 100
+)
+
+type SentReviewResponse struct {
+	ReviewID     string              `json:"review_id"`
+	MatchID      string              `json:"match_id"`
+	ToUserID     string              `json:"to_user_id"`
+	ToUsername   string              `json:"to_username"`
+	ToIconURL    string              `json:"to_icon_url"`
+	WorkID       string              `json:"work_id"` // 作品が削除されていれば空
+	WorkTitle    string              `json:"work_title"`
+	WorkImageURL string              `json:"work_image_url"`
+	Comment      string              `json:"comment"`
+	Sections     []guideline.Section `json:"sections"`
+	ReviewedBack bool                `json:"reviewed_back"` // 相手も自分にレビューを送ったか
+	Status       string              `json:"status"`
+	Reactions    []string            `json:"reactions"`
+	ReadAt       *string             `json:"read_at"`
+	EditedAt     *string             `json:"edited_at"`
+	CreatedAt    string              `json:"created_at"`
+}
+
+type SentReviewListResponse struct {
+	Reviews    []SentReviewResponse `json:"reviews"`
+	NextCursor *string              `json:"next_cursor"` // 続きがなければ null
+}
+
+// GetSentReviews は自分が送ったレビューと、その既読・リアクションの状況を返す
+// sort=desc（新しい順、既定）/ asc（古い順）で、cursor には前のページの next_cursor を渡す
+func (s *Server) GetSentReviews(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	limit := defaultSentReviewLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("limit"))
+			return
+		}
+		limit = min(n, maxSentReviewLimit)
+	}
+
+	ascending := false
+	switch c.DefaultQuery("sort", "desc") {
+	case "desc":
+	case "asc":
+		ascending = true
+	default:
+		apierror.Abort(c, apierror.Invalid("sort"))
+		return
+	}
+
+	cursor, ok := queryCursor(c)
+	if !ok {
+		return
+	}
+	// 1 件多く取って続きがあるか判定する
+	list, err := s.Reviews.Sent(context.Background(), userID, cursor, ascending, limit+1)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	resp := SentReviewListResponse{Reviews: []SentReviewResponse{}}
+
+	for _, item := range list {
+		if len(resp.Reviews) == limit {
+			last := list[limit-1]
+			next := encodeCursor(last.CreatedAt, last.ID)
+			resp.NextCursor = &next
+			break
+		}
+
+		const DefaultWorkImagePath = "images/default.png"
+
+		r := SentReviewResponse{
+			ReviewID:     item.ID,
+			MatchID:      item.MatchID,
+			ToUserID:     item.ToUserID,
+			ToUsername:   item.ToUsername,
+			WorkID:       item.WorkID,
+			WorkTitle:    item.WorkTitle,
+			Comment:      item.Comment,
+			Sections:     item.Sections,
+			ReviewedBack: item.ReviewedBack,
+			Reactions:    item.Reactions,
+		}
+
+		if item.ToIconPath != nil {
+			r.ToIconURL = s.Storage.PublicURL(*item.ToIconPath)
+		} else {
+			r.ToIconURL = s.Storage.PublicURL(DefaultIconPath)
+		}
+		if item.WorkImagePath != nil {
+			r.WorkImageURL = s.Storage.PublicURL(*item.WorkImagePath)
+		} else {
+			r.WorkImageURL = s.Storage.PublicURL(DefaultWorkImagePath)
+		}
+
+		// 相手がレビューを返していれば読める状態
+		r.Status = sentReviewStatus(r.ReviewedBack, item.ReadAt)
+		r.ReadAt = formatTimePtr(item.ReadAt)
+		r.EditedAt = formatTimePtr(item.EditedAt)
+		r.CreatedAt = item.CreatedAt.Format(time.RFC3339)
+
+		resp.Reviews = append(resp.Reviews, r)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func sentReviewStatus(unlocked bool, readAt *time.Time) string {
+	switch {
+	case readAt != nil:
+		return SentReviewRead
+	case unlocked:
+		return SentReviewDelivered
+	default:
+		return SentReviewLocked
+	}
+}
+
+func formatTimePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
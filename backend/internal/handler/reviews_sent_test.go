@@ -0,0 +1,144 @@
+// backend/internal/handler/reviews_sent_test.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func getSentReviewPage(t *testing.T, r http.Handler, query url.Values) SentReviewListResponse {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/sent?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp SentReviewListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return resp
+}
+
+func TestGetSentReviews_PaginationAndProfile(t *testing.T) {
+	r := setupTestRouter(withReviewReactions)
+
+	me := createTestUser(t)
+	myWork := createTestWork(t, me)
+
+	// 3 人にレビューを送る（古い順に partners[0..2]）
+	var partners, reviewIDs []string
+	for i := 0; i < 3; i++ {
+		partner := createTestUser(t)
+		work := createTestWork(t, partner)
+		matchID := createTestMatch(t, me, partner, myWork, work)
+		reviewIDs = append(reviewIDs, createTestReview(t, matchID, me, partner, work, "色の重ね方が丁寧"))
+		partners = append(partners, partner)
+
+		// 最初の相手だけレビューを返してくれた
+		if i == 0 {
+			_ = createTestReview(t, matchID, partner, me, myWork, "thanks!")
+		}
+	}
+
+	// 新しい順に 2 件ずつ
+	page := getSentReviewPage(t, r, url.Values{"user_id": {me}, "limit": {"2"}})
+	if len(page.Reviews) != 2 || page.NextCursor == nil {
+		t.Fatalf("expected 2 reviews with next cursor, got %d (%v)", len(page.Reviews), page.NextCursor)
+	}
+	if page.Reviews[0].ReviewID != reviewIDs[2] || page.Reviews[1].ReviewID != reviewIDs[1] {
+		t.Fatalf("unexpected order: %+v", page.Reviews)
+	}
+	if page.Reviews[0].ToUserID != partners[2] || page.Reviews[0].ToUsername == "" || page.Reviews[0].WorkTitle == "" {
+		t.Fatalf("expected recipient and work info: %+v", page.Reviews[0])
+	}
+
+	page = getSentReviewPage(t, r, url.Values{"user_id": {me}, "limit": {"2"}, "cursor": {*page.NextCursor}})
+	if len(page.Reviews) != 1 || page.NextCursor != nil {
+		t.Fatalf("expected last page with 1 review, got %d (%v)", len(page.Reviews), page.NextCursor)
+	}
+	if page.Reviews[0].ReviewID != reviewIDs[0] || !page.Reviews[0].ReviewedBack {
+		t.Fatalf("expected oldest review reviewed back: %+v", page.Reviews[0])
+	}
+
+	// 古い順
+	page = getSentReviewPage(t, r, url.Values{"user_id": {me}, "sort": {"asc"}})
+	if len(page.Reviews) != 3 || page.Reviews[0].ReviewID != reviewIDs[0] {
+		t.Fatalf("unexpected ascending order: %+v", page.Reviews)
+	}
+	if page.Reviews[1].ReviewedBack {
+		t.Fatalf("expected review not reviewed back: %+v", page.Reviews[1])
+	}
+}
+
+// 同じ時刻に送ったレビューもページの境目で抜けたり重複したりしない
+func TestGetSentReviews_SameCreatedAt(t *testing.T) {
+	align := freezeCreatedAt(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := setupTestRouter(withReviewReactions)
+
+	me := createTestUser(t)
+	myWork := createTestWork(t, me)
+
+	want := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		partner := createTestUser(t)
+		work := createTestWork(t, partner)
+		matchID := createTestMatch(t, me, partner, myWork, work)
+		want[createTestReview(t, matchID, me, partner, work, "色の重ね方が丁寧")] = true
+	}
+	align("reviews", "from_user_id", me)
+
+	for _, sort := range []string{"desc", "asc"} {
+		seen := map[string]bool{}
+		query := url.Values{"user_id": {me}, "limit": {"1"}, "sort": {sort}}
+		for {
+			page := getSentReviewPage(t, r, query)
+			for _, rv := range page.Reviews {
+				if seen[rv.ReviewID] {
+					t.Fatalf("%s: review %s returned twice", sort, rv.ReviewID)
+				}
+				seen[rv.ReviewID] = true
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			query.Set("cursor", *page.NextCursor)
+		}
+		if len(seen) != len(want) {
+			t.Fatalf("%s: expected %d reviews, got %d", sort, len(want), len(seen))
+		}
+	}
+}
+
+func TestGetSentReviews_InvalidCursor(t *testing.T) {
+	r := setupTestRouter(withReviewReactions)
+
+	query := url.Values{"user_id": {createTestUser(t)}, "cursor": {"2026-01-01T00:00:00Z"}}
+	req := httptest.NewRequest(http.MethodGet, "/reviews/sent?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestGetSentReviews_InvalidSort(t *testing.T) {
+	r := setupTestRouter(withReviewReactions)
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/sent?user_id=x&sort=random", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
@@ -70,3 +70,53 @@ func TestGetReceivedReviewsSuccess(t *testing.T) {
 		t.Fatal("work_title is empty")
 	}
 }
+
+func TestGetReceivedReviews_IncludeLocked(t *testing.T) {
+	r := setupTestRouter(withReceivedReviews)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	// A → B のみ（B から見るとロック中）
+	_ = createTestReview(t, matchID, userA, userB, workB, "secret comment")
+
+	// 既定ではロック中のレビューは返らない
+	req := httptest.NewRequest(http.MethodGet, "/reviews?user_id="+userB, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp []ReceivedReviewResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp) != 0 {
+		t.Fatalf("expected no reviews, got %d", len(resp))
+	}
+
+	// include_locked=true なら本文なしのプレースホルダが返る
+	req = httptest.NewRequest(http.MethodGet, "/reviews?include_locked=true&user_id="+userB, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	resp = nil
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp) != 1 {
+		t.Fatalf("expected 1 placeholder, got %d", len(resp))
+	}
+	if !resp[0].IsLocked {
+		t.Fatal("expected is_locked=true")
+	}
+	if resp[0].Comment != "" || len(resp[0].Sections) != 0 {
+		t.Fatalf("locked review must not include text: %+v", resp[0])
+	}
+	if resp[0].UserID != userA || resp[0].Username == "" {
+		t.Fatalf("expected reviewer info for teaser: %+v", resp[0])
+	}
+}
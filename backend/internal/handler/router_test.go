@@ -1,56 +1,154 @@
 // backend/internal/handler/router_test.go
 package handler
 
-import "github.com/gin-gonic/gin"
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+)
 
 func setupTestRouter(handlers ...func(*gin.Engine)) *gin.Engine {
 	r := gin.Default()
+	r.Use(apierror.RequestID())
 	for _, h := range handlers {
 		h(r)
 	}
 	return r
 }
 
+// postJSON は JSON ボディ付きのリクエストを送ってレスポンスを返す
+func postJSON(r http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewBuffer(b))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func withSignup(r *gin.Engine) {
-	r.POST("/sign-up", Signup)
+	r.POST("/sign-up", testServer.Signup)
 }
 
 func withLogin(r *gin.Engine) {
-	r.POST("/login", Login)
+	r.POST("/login", testServer.Login)
 }
 
 func withMe(r *gin.Engine) {
-	r.GET("/me", GetMyProfile)
+	r.GET("/me", testServer.GetMyProfile)
 }
 
 func withSwipe(r *gin.Engine) {
-	r.POST("/swipe", PostSwipe)
+	r.POST("/swipe", testServer.PostSwipe)
 }
 
 func withReview(r *gin.Engine) {
-	r.POST("/review", PostReview)
+	r.POST("/review", testServer.PostReview)
 }
 
 func withReceivedReviews(r *gin.Engine) {
-	r.GET("/reviews", GetReceivedReviews)
+	r.GET("/reviews", testServer.GetReceivedReviews)
 }
 
 func withWorks(r *gin.Engine) {
-	r.GET("/works", GetWorks)
+	r.GET("/works", testServer.GetWorks)
 }
 
 func withMatches(r *gin.Engine) {
-	r.GET("/matches", GetMatches)
+	r.GET("/matches", testServer.GetMatches)
 }
 
 func withPostWork(r *gin.Engine) {
-	r.POST("/work", PostWork)
+	r.POST("/work", testServer.PostWork)
 }
 
 func withUpdateProfile(r *gin.Engine) {
-	r.POST("/update-profile", UpdateMyProfile)
+	r.POST("/update-profile", testServer.UpdateMyProfile)
 }
 
 func withMyWorks(r *gin.Engine) {
-	r.GET("/my-works", GetMyWorks)
+	r.GET("/my-works", testServer.GetMyWorks)
+}
+
+func withEvents(r *gin.Engine) {
+	r.GET("/events", testServer.StreamEvents)
+}
+
+func withNotifications(r *gin.Engine) {
+	r.GET("/notifications", testServer.GetNotifications)
+	r.GET("/notifications/unread-count", testServer.GetUnreadNotificationCount)
+	r.POST("/notifications/read", testServer.MarkNotificationsRead)
+	r.GET("/notifications/preferences", testServer.GetNotificationPreferences)
+	r.PUT("/notifications/preferences", testServer.UpdateNotificationPreferences)
+}
+
+func withDevices(r *gin.Engine) {
+	r.POST("/devices", testServer.RegisterDevice)
+	r.DELETE("/devices", testServer.UnregisterDevice)
+}
+
+func withMessages(r *gin.Engine) {
+	r.GET("/matches/:id/messages", testServer.GetMessages)
+	r.POST("/matches/:id/messages", testServer.PostMessage)
+	r.POST("/matches/:id/messages/read", testServer.MarkMessagesRead)
+}
+
+func withBlocks(r *gin.Engine) {
+	r.GET("/blocks", testServer.GetBlockedUsers)
+	r.POST("/blocks", testServer.BlockUser)
+	r.DELETE("/blocks", testServer.UnblockUser)
+}
+
+func withReviewGuidelines(r *gin.Engine) {
+	r.GET("/review-guidelines", testServer.GetReviewGuidelines)
+}
+
+func withReviewStatus(r *gin.Engine) {
+	r.GET("/reviews/status", testServer.GetReviewStatus)
+}
+
+func withReviewEdit(r *gin.Engine) {
+	r.PATCH("/reviews/:id", testServer.UpdateReview)
+}
+
+func withReviewReactions(r *gin.Engine) {
+	r.GET("/reviews/sent", testServer.GetSentReviews)
+	r.POST("/reviews/:id/read", testServer.MarkReviewRead)
+	r.POST("/reviews/:id/reactions", testServer.ReactToReview)
+}
+
+func withReports(r *gin.Engine) {
+	r.POST("/reports", testServer.CreateReport)
+	r.GET("/admin/reports", testServer.GetReports)
+	r.POST("/admin/reports/:id/triage", testServer.TriageReport)
+	r.POST("/admin/reports/:id/resolve", testServer.ResolveReport)
+}
+
+func withPublicProfile(r *gin.Engine) {
+	r.GET("/users/:id", testServer.GetPublicProfile)
+}
+
+func withStats(r *gin.Engine) {
+	r.GET("/me/stats", testServer.GetMyStats)
+}
+
+func withHandles(r *gin.Engine) {
+	r.PUT("/me/handle", testServer.UpdateMyHandle)
+	r.GET("/handles/:handle", testServer.ResolveHandle)
+}
+
+func withPatchProfile(r *gin.Engine) {
+	r.PATCH("/me", testServer.PatchMyProfile)
+	r.PUT("/me/icon", testServer.ReplaceMyIcon)
+	r.DELETE("/me/icon", testServer.DeleteMyIcon)
+}
+
+func withAccount(r *gin.Engine) {
+	r.POST("/me/export", testServer.ExportMyData)
+	r.DELETE("/me", testServer.DeleteMyAccount)
+	r.POST("/me/restore", testServer.RestoreMyAccount)
 }
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/background"
+	"github.com/p2hacks2025/pre-12/backend/internal/quality"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
+	"github.com/p2hacks2025/pre-12/backend/internal/textfilter"
+)
+
+// Config はハンドラが使う外部サービスの接続先と動作モード（main が設定から作る）
+// 空の項目は NewServer が既定値で埋める
+type Config struct {
+	// Storage は画像を置く Supabase Storage
+	Storage storage.Config
+	// Broker はイベント配信（既定は 1 プロセス内の Hub）
+	Broker realtime.Broker
+	// QualityMode は低品質なレビューの扱い（既定は reject）
+	QualityMode quality.Mode
+	// TextFilter は禁止語リスト（既定は埋め込みのリスト）、TextFilterMode は禁止語を含むテキストの扱い（既定は reject）
+	TextFilter     *textfilter.Filter
+	TextFilterMode textfilter.Mode
+}
+
+// Server はハンドラが使う依存をまとめ、ルートを登録する
+// データはすべてリポジトリ越しに読み書きする
+type Server struct {
+	repository.Repositories
+	Config
+	Matcher  *service.Matcher
+	Notifier *service.Notifier
+
+	// Tasks はレスポンスを返したあとに続ける処理（マッチ判定・通知）
+	// 終了時に main が Shutdown で待つ
+	Tasks *background.Group
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewServer(repos repository.Repositories, cfg Config) *Server {
+	if cfg.Broker == nil {
+		cfg.Broker = realtime.NewHub()
+	}
+	if cfg.QualityMode == "" {
+		cfg.QualityMode = quality.ModeReject
+	}
+	if cfg.TextFilter == nil {
+		cfg.TextFilter = textfilter.NewDefault()
+	}
+	if cfg.TextFilterMode == "" {
+		cfg.TextFilterMode = textfilter.ModeReject
+	}
+
+	notifier := service.NewNotifier(repos, cfg.Broker)
+	return &Server{
+		Repositories: repos,
+		Config:       cfg,
+		Matcher:      service.NewMatcher(repos, notifier),
+		Notifier:     notifier,
+		Tasks:        background.NewGroup(),
+		closing:      make(chan struct{}),
+	}
+}
+
+// CloseStreams はイベント配信（SSE/WebSocket）の接続を終わらせる
+// http.Server.Shutdown は張りっぱなしの接続を待ち続けるので、その前に呼ぶ
+func (s *Server) CloseStreams() {
+	s.closeOnce.Do(func() { close(s.closing) })
+}
+
+// Routes は API のルートをすべて登録する
+func (s *Server) Routes(r gin.IRouter) {
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "ok"})
+	})
+
+	r.GET("/supabase-health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"supabase": "ok"})
+	})
+
+	r.GET("/debug/users", s.DebugGetUsers)
+
+	r.GET("/debug/works", s.DebugGetWorks)
+
+	r.POST("/sign-up", s.Signup)
+
+	r.POST("/login", s.Login)
+
+	r.POST("/update-profile", s.UpdateMyProfile)
+
+	r.GET("/me", s.GetMyProfile)
+
+	r.PATCH("/me", s.PatchMyProfile)
+
+	r.PUT("/me/icon", s.ReplaceMyIcon)
+
+	r.DELETE("/me/icon", s.DeleteMyIcon)
+
+	r.GET("/me/stats", s.GetMyStats)
+
+	r.POST("/me/export", s.ExportMyData)
+
+	r.DELETE("/me", s.DeleteMyAccount)
+
+	r.POST("/me/restore", s.RestoreMyAccount)
+
+	r.GET("/users/:id", s.GetPublicProfile)
+
+	r.PUT("/me/handle", s.UpdateMyHandle)
+
+	r.GET("/handles/:handle", s.ResolveHandle)
+
+	r.GET("/my-works", s.GetMyWorks)
+
+	r.POST("/work", s.PostWork) // /workに修正
+
+	r.GET("/works", s.GetWorks)
+
+	r.POST("/swipe", s.PostSwipe)
+
+	r.GET("/matches", s.GetMatches)
+
+	r.POST("/review", s.PostReview)
+
+	r.GET("/reviews", s.GetReceivedReviews)
+
+	r.GET("/reviews/status", s.GetReviewStatus)
+
+	r.GET("/reviews/sent", s.GetSentReviews)
+
+	r.PATCH("/reviews/:id", s.UpdateReview)
+
+	r.POST("/reviews/:id/read", s.MarkReviewRead)
+
+	r.POST("/reviews/:id/reactions", s.ReactToReview)
+
+	r.GET("/review-guidelines", s.GetReviewGuidelines)
+
+	r.GET("/events", s.StreamEvents)
+
+	r.GET("/notifications", s.GetNotifications)
+
+	r.GET("/notifications/unread-count", s.GetUnreadNotificationCount)
+
+	r.POST("/notifications/read", s.MarkNotificationsRead)
+
+	r.GET("/notifications/preferences", s.GetNotificationPreferences)
+
+	r.PUT("/notifications/preferences", s.UpdateNotificationPreferences)
+
+	r.POST("/devices", s.RegisterDevice)
+
+	r.DELETE("/devices", s.UnregisterDevice)
+
+	r.GET("/matches/:id/messages", s.GetMessages)
+
+	r.POST("/matches/:id/messages", s.PostMessage)
+
+	r.POST("/matches/:id/messages/read", s.MarkMessagesRead)
+
+	r.GET("/blocks", s.GetBlockedUsers)
+
+	r.POST("/blocks", s.BlockUser)
+
+	r.DELETE("/blocks", s.UnblockUser)
+
+	r.POST("/reports", s.CreateReport)
+
+	r.GET("/admin/reports", s.GetReports)
+
+	r.POST("/admin/reports/:id/triage", s.TriageReport)
+
+	r.POST("/admin/reports/:id/resolve", s.ResolveReport)
+}
@@ -2,49 +2,54 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 )
 
 type SignupRequest struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Username string `json:"username" binding:"required,notblank,max=30"` // MaxUsernameLength
+	Email    string `json:"email" binding:"required,email,max=254"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
 }
 
-func Signup(c *gin.Context) {
+func (s *Server) Signup(c *gin.Context) {
 	var req SignupRequest
-	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &req) {
 		return
 	}
+	req.Username = strings.TrimSpace(req.Username)
+
+	ctx := context.Background()
 
 	// すでに同じメールアドレスがあるかチェック
-	var exists bool
-	emailLower := strings.ToLower(req.Email)
-	err := db.Pool.QueryRow(context.Background(),
-		"SELECT EXISTS(SELECT 1 FROM public.users WHERE LOWER(email)=$1)",
-		emailLower).Scan(&exists)
+	exists, err := s.Users.EmailExists(ctx, req.Email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		apierror.Abort(c, err)
 		return
 	}
 	if exists {
-		c.JSON(http.StatusConflict, gin.H{"error": "email already registered"})
+		apierror.Abort(c, apierror.New(apierror.CodeEmailTaken))
 		return
 	}
 
-	// 新しいユーザーを登録
-	var id string
-	err = db.Pool.QueryRow(context.Background(),
-		"INSERT INTO public.users (username, email, password) VALUES ($1, $2, $3) RETURNING id",
-		req.Username, emailLower, req.Password).Scan(&id)
+	// 新しいユーザーを登録（同時に同じメールアドレスで登録されたときは一意制約で弾かれる）
+	id, err := s.Users.Create(ctx, repository.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		apierror.Abort(c, apierror.New(apierror.CodeEmailTaken))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user"})
+		apierror.Abort(c, err)
 		return
 	}
 
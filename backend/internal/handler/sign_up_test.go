@@ -2,7 +2,6 @@ package handler
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,7 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 /*
@@ -52,15 +51,7 @@ func TestSignupSuccessAndCleanup(t *testing.T) {
 		t.Fatal("user_id is empty")
 	}
 
-	t.Cleanup(func() {
-		if _, err := db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.users WHERE id=$1",
-			resp.UserID,
-		); err != nil {
-			t.Errorf("cleanup failed: %v", err)
-		}
-	})
+	cleanupRow(t, "users", resp.UserID)
 }
 
 /*
@@ -100,15 +91,7 @@ func TestSignupEmailAlreadyExists(t *testing.T) {
 		t.Fatal("failed to parse response")
 	}
 
-	t.Cleanup(func() {
-		if _, err := db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.users WHERE id=$1",
-			resp.UserID,
-		); err != nil {
-			t.Errorf("cleanup failed: %v", err)
-		}
-	})
+	cleanupRow(t, "users", resp.UserID)
 
 	// --- 2回目（同じメール → 409） ---
 	body.Username = "user2"
@@ -128,3 +111,45 @@ func TestSignupEmailAlreadyExists(t *testing.T) {
 		t.Fatalf("expected 409, got %d: %s", w2.Code, w2.Body.String())
 	}
 }
+
+/*
+異常系：入力チェック（項目ごとにまとめて返す）
+*/
+func TestSignupValidation(t *testing.T) {
+	r := setupTestRouter(withSignup)
+
+	w := postJSON(r, http.MethodPost, "/sign-up", SignupRequest{
+		Username: "   ",
+		Email:    "not-an-email",
+		Password: "short",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Code    apierror.Code         `json:"code"`
+		Details []apierror.FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if resp.Code != apierror.CodeValidationFailed {
+		t.Fatalf("unexpected code: %s", resp.Code)
+	}
+
+	got := map[string]string{}
+	for _, f := range resp.Details {
+		got[f.Field] = f.Reason
+	}
+	want := map[string]string{
+		"username": apierror.ReasonRequired,
+		"email":    apierror.ReasonInvalid,
+		"password": apierror.ReasonTooShort,
+	}
+	for field, reason := range want {
+		if got[field] != reason {
+			t.Errorf("%s: expected %s, got %q", field, reason, got[field])
+		}
+	}
+}
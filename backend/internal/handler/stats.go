@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+// GetMyStats はクリエイター向けの集計（作品ごとの反応・日別推移など）を返す
+// 集計はインスタンスごとに数分キャッシュする
+func (s *Server) GetMyStats(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	days := service.DefaultStatsDays
+	if v := c.Query("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			apierror.Abort(c, apierror.Invalid("days"))
+			return
+		}
+		days = min(n, service.MaxStatsDays)
+	}
+
+	stats, err := service.GetUserStats(context.Background(), s.Stats, userID, days)
+	if err != nil {
+		apierror.Abort(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(service.StatsCacheTTL.Seconds())))
+	c.JSON(http.StatusOK, stats)
+}
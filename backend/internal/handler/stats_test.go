@@ -0,0 +1,65 @@
+// backend/internal/handler/stats_test.go
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+)
+
+func TestGetMyStats(t *testing.T) {
+	r := setupTestRouter(withStats)
+
+	creator := createTestUser(t)
+	fanA := createTestUser(t)
+	fanB := createTestUser(t)
+	passer := createTestUser(t)
+
+	work := createTestWork(t, creator)
+	workA := createTestWork(t, fanA)
+
+	createTestSwipe(t, fanA, work, true)
+	createTestSwipe(t, fanB, work, true)
+	createTestSwipe(t, passer, work, false)
+
+	matchID := createTestMatch(t, creator, fanA, work, workA)
+	_ = createTestReview(t, matchID, fanA, creator, work, "色の重ね方が丁寧")
+	_ = createTestReview(t, matchID, creator, fanA, workA, "光の使い方がいい")
+
+	req := httptest.NewRequest(http.MethodGet, "/me/stats?days=7&user_id="+creator, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var stats service.UserStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	if stats.Likes != 2 || stats.Passes != 1 || stats.Matches != 1 {
+		t.Fatalf("unexpected totals: %+v", stats)
+	}
+	if stats.LikeRate == nil || *stats.LikeRate < 0.66 || *stats.LikeRate > 0.67 {
+		t.Fatalf("unexpected like rate: %v", stats.LikeRate)
+	}
+	if stats.ReviewsSent != 1 || stats.ReviewsReceived != 1 || stats.AvgResponseSeconds == nil {
+		t.Fatalf("unexpected review stats: %+v", stats)
+	}
+	if len(stats.Works) != 1 || stats.Works[0].WorkID != work || stats.Works[0].Likes != 2 {
+		t.Fatalf("unexpected work stats: %+v", stats.Works)
+	}
+
+	if len(stats.Daily) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(stats.Daily))
+	}
+	today := stats.Daily[len(stats.Daily)-1]
+	if today.Likes != 2 || today.Passes != 1 || today.Matches != 1 {
+		t.Fatalf("unexpected today stats: %+v", today)
+	}
+}
@@ -2,41 +2,42 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/service"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 )
 
 // SwipeRequest は Flutter から送られてくるスワイプ情報
 type SwipeRequest struct {
-	FromUserID string `json:"from_user_id"`
-	ToWorkID   string `json:"to_work_id"`
+	FromUserID string `json:"from_user_id" binding:"required,uuid"`
+	ToWorkID   string `json:"to_work_id" binding:"required,uuid"`
 	IsLike     bool   `json:"is_like"`
 }
 
-func PostSwipe(c *gin.Context) {
+func (s *Server) PostSwipe(c *gin.Context) {
 	var req SwipeRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	ctx := context.Background()
 
 	// ① スワイプ保存（同期）
-	res, err := db.Pool.Exec(ctx, `
-		INSERT INTO public.swipes (from_user_id, to_work_id, to_work_user_id, is_like)
-		SELECT $1, w.id, w.user_id, $2
-		FROM public.works w
-		WHERE w.id = $3 AND w.user_id <> $1
-		ON CONFLICT (from_user_id, to_work_id) DO UPDATE
-		SET is_like = EXCLUDED.is_like, created_at = now()
-	`, req.FromUserID, req.IsLike, req.ToWorkID)
-
-	if err != nil || res.RowsAffected() == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "swipe failed"})
+	err := s.Swipes.Save(ctx, req.FromUserID, req.ToWorkID, req.IsLike)
+	switch {
+	case errors.Is(err, repository.ErrMissingReference):
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		// 作品がない、または自分の作品
+		apierror.Abort(c, apierror.Invalid("to_work_id"))
+		return
+	case err != nil:
+		apierror.Abort(c, err)
 		return
 	}
 
@@ -45,6 +46,10 @@ func PostSwipe(c *gin.Context) {
 
 	// ③ マッチ判定は非同期
 	if req.IsLike {
-		go service.CheckAndCreateMatch(req.FromUserID, req.ToWorkID)
+		s.Tasks.Go("match", func(ctx context.Context) {
+			if _, err := s.Matcher.CheckAndCreateMatch(ctx, req.FromUserID, req.ToWorkID); err != nil {
+				log.Printf("match check failed: %v", err)
+			}
+		})
 	}
 }
@@ -7,9 +7,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 /*
@@ -43,19 +42,15 @@ func TestPostSwipeSuccess(t *testing.T) {
 		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// DBに保存されているか
-	var count int
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`SELECT COUNT(*) FROM swipes WHERE from_user_id=$1 AND to_work_id=$2`,
-		userA, workB,
-	).Scan(&count)
-
+	// 保存されていれば、スワイプ済みとしてホームに出なくなる
+	unswiped, err := testServer.Works.UnswipedIDs(context.Background(), userA)
 	if err != nil {
-		t.Fatalf("db check failed: %v", err)
+		t.Fatalf("failed to load unswiped works: %v", err)
 	}
-	if count != 1 {
-		t.Fatalf("expected 1 swipe, got %d", count)
+	for _, id := range unswiped {
+		if id == workB {
+			t.Fatalf("swiped work %s is still unswiped", workB)
+		}
 	}
 }
 
@@ -126,24 +121,40 @@ func TestSwipeCreatesMatch(t *testing.T) {
 	like(userB, workA)
 	like(userA, workB)
 
-	// goroutine の完了待ち
-	time.Sleep(300 * time.Millisecond)
-
-	var count int
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		SELECT COUNT(*) FROM matches
-		WHERE (user1_id=$1 AND user2_id=$2)
-		   OR (user1_id=$2 AND user2_id=$1)
-		`,
-		userA, userB,
-	).Scan(&count)
+	// マッチ判定の完了待ち
+	testServer.Tasks.Wait()
 
+	matches, err := testServer.Matches.ListForUser(context.Background(), userA)
 	if err != nil {
-		t.Fatalf("db check failed: %v", err)
+		t.Fatalf("failed to load matches: %v", err)
+	}
+	if len(matches) != 1 || matches[0].UserID != userB {
+		t.Fatalf("expected 1 match with %s, got %+v", userB, matches)
+	}
+}
+
+/*
+========================
+異常系：ID が UUID でない（DB に流さず 400）
+========================
+*/
+func TestPostSwipeInvalidIDs(t *testing.T) {
+	r := setupTestRouter(withSwipe)
+
+	w := postJSON(r, http.MethodPost, "/swipe", SwipeRequest{
+		FromUserID: "1",
+		ToWorkID:   "work-1",
+		IsLike:     true,
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Details []apierror.FieldError `json:"details"`
 	}
-	if count != 1 {
-		t.Fatalf("expected 1 match, got %d", count)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.Details) != 2 {
+		t.Fatalf("expected errors for both ids, got %+v", resp.Details)
 	}
 }
@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 )
 
 /*
@@ -16,36 +18,45 @@ DB helpers only
 ========================
 */
 
+// testServer はテストで使うハンドラとリポジトリの持ち主（TestMain で作る）
+var testServer *Server
+
+// testPool は TEST_BACKEND=postgres のときだけ入る接続（後片付けに使う）
+var testPool *pgxpool.Pool
+
 type CleanupT interface {
 	Fatalf(string, ...any)
 	Cleanup(func())
 }
 
+// cleanupRow はテスト後に Postgres から行を消す
+// メモリ上のリポジトリはテストの間だけのものなので消さない
+func cleanupRow(t CleanupT, table, id string) {
+	if testPool == nil {
+		return
+	}
+	t.Cleanup(func() {
+		_, _ = testPool.Exec(
+			context.Background(),
+			"DELETE FROM public."+table+" WHERE id=$1",
+			id,
+		)
+	})
+}
+
 func createTestUser(t CleanupT) string {
 	email := fmt.Sprintf("test_%d@example.com", time.Now().UnixNano())
 
-	var userID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		INSERT INTO public.users (username, email, password)
-		VALUES ($1, $2, 'dummy')
-		RETURNING id
-		`,
-		"testuser", email,
-	).Scan(&userID)
-
+	userID, err := testServer.Users.Create(context.Background(), repository.User{
+		Username: "testuser",
+		Email:    email,
+		Password: "dummy",
+	})
 	if err != nil {
 		t.Fatalf("failed to create user: %v", err)
 	}
 
-	t.Cleanup(func() {
-		_, _ = db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.users WHERE id=$1",
-			userID,
-		)
-	})
+	cleanupRow(t, "users", userID)
 
 	return userID
 }
@@ -55,109 +66,58 @@ func createTestWork(t CleanupT, userID string) string {
 	imagePath := fmt.Sprintf("/dummy/test_%d.png", time.Now().UnixNano())
 	title := fmt.Sprintf("test work %d", time.Now().UnixNano()) // タイトルも必要ならユニーク化
 
-	var workID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		INSERT INTO public.works (user_id, title, image_path)
-		VALUES ($1, $2, $3)
-		RETURNING id
-		`,
-		userID,
-		title,
-		imagePath,
-	).Scan(&workID)
-
+	workID, err := testServer.Works.Save(context.Background(), repository.Work{
+		UserID:    userID,
+		Title:     title,
+		ImagePath: &imagePath,
+	})
 	if err != nil {
 		t.Fatalf("failed to create work: %v", err)
 	}
 
-	t.Cleanup(func() {
-		_, _ = db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.works WHERE id=$1",
-			workID,
-		)
-	})
+	cleanupRow(t, "works", workID)
 
 	return workID
 }
 
-// createTestSwipe はスワイプ済みレコードを作成
-func createTestSwipe(t testing.TB, fromUserID, toWorkID, toWorkUserID string, isLike bool) string {
-	var swipeID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-        INSERT INTO public.swipes (from_user_id, to_work_id, to_work_user_id, is_like)
-        VALUES ($1, $2, $3, $4)
-        RETURNING id
-        `,
-		fromUserID, toWorkID, toWorkUserID, isLike,
-	).Scan(&swipeID)
-
-	if err != nil {
+// createTestSwipe はスワイプ済みレコードを作成（ユーザーを消すと一緒に消える）
+func createTestSwipe(t testing.TB, fromUserID, toWorkID string, isLike bool) {
+	if err := testServer.Swipes.Save(context.Background(), fromUserID, toWorkID, isLike); err != nil {
 		t.Fatalf("failed to create swipe: %v", err)
 	}
-
-	t.Cleanup(func() {
-		_, _ = db.Pool.Exec(context.Background(), "DELETE FROM public.swipes WHERE id=$1", swipeID)
-	})
-
-	return swipeID
 }
 
 func createTestMatch(t testing.TB, user1ID, user2ID, work1ID, work2ID string) string {
-	var matchID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		INSERT INTO public.matches (user1_id, user2_id, work1_id, work2_id)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id
-		`,
-		user1ID, user2ID, work1ID, work2ID,
-	).Scan(&matchID)
-
+	match, err := testServer.Matches.Create(context.Background(), repository.Match{
+		User1ID: user1ID,
+		User2ID: user2ID,
+		Work1ID: work1ID,
+		Work2ID: work2ID,
+	})
 	if err != nil {
 		t.Fatalf("failed to create match: %v", err)
 	}
 
-	t.Cleanup(func() {
-		_, _ = db.Pool.Exec(context.Background(), "DELETE FROM public.matches WHERE id=$1", matchID)
-	})
+	cleanupRow(t, "matches", match.ID)
 
-	return matchID
+	return match.ID
 }
 
 func createTestReview(t testing.TB, matchID, fromUserID, toUserID, workID, comment string) string {
-	var reviewID string
-	err := db.Pool.QueryRow(
-		context.Background(),
-		`
-		INSERT INTO public.reviews (
-			match_id,
-			from_user_id,
-			to_user_id,
-			work_id,
-			comment
-		)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
-		`,
-		matchID,
-		fromUserID,
-		toUserID,
-		workID,
-		comment,
-	).Scan(&reviewID)
+	reviewID, err := testServer.Reviews.Create(context.Background(), repository.Review{
+		MatchID:      matchID,
+		FromUserID:   fromUserID,
+		ToUserID:     toUserID,
+		WorkID:       workID,
+		Comment:      comment,
+		Sections:     []guideline.Section{},
+		QualityFlags: []string{},
+	})
 	if err != nil {
 		t.Fatalf("failed to create review: %v", err)
 	}
 
-	t.Cleanup(func() {
-		_, _ = db.Pool.Exec(context.Background(), "DELETE FROM public.reviews WHERE id=$1", reviewID)
-	})
+	cleanupRow(t, "reviews", reviewID)
 
 	return reviewID
 }
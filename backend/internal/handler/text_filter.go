@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+	"github.com/p2hacks2025/pre-12/backend/internal/textfilter"
+)
+
+// textField はテキストフィルタにかける入力欄
+type textField struct {
+	Name  string
+	Value *string
+}
+
+// filterText は禁止語を検査する
+//   - reject モード: 禁止語があれば 400 を返して ok=false
+//   - mask モード: 禁止語を伏せ字にして Value を書き換え、伏せ字にした欄の名前を返す
+func (s *Server) filterText(c *gin.Context, fields ...textField) (masked []string, ok bool) {
+	for _, f := range fields {
+		if s.TextFilterMode == textfilter.ModeReject {
+			if s.TextFilter.Contains(*f.Value) {
+				apierror.Abort(c, apierror.New(apierror.CodeInappropriateText).WithDetails([]apierror.FieldError{
+					{Field: f.Name, Reason: apierror.ReasonInappropriate},
+				}))
+				return nil, false
+			}
+			continue
+		}
+
+		if v, hit := s.TextFilter.Mask(*f.Value); hit {
+			*f.Value = v
+			masked = append(masked, f.Name)
+		}
+	}
+	return masked, true
+}
+
+// reportMaskedText は伏せ字にした投稿をモデレーションの確認待ちに入れる
+func (s *Server) reportMaskedText(targetType, targetID string, masked []string) {
+	if len(masked) == 0 || targetID == "" {
+		return
+	}
+	service.CreateSystemReport(context.Background(), s.Reports, targetType, targetID,
+		service.ReportReasonInappropriate, "text filter: "+strings.Join(masked, ", "))
+}
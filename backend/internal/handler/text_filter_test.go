@@ -0,0 +1,94 @@
+// backend/internal/handler/text_filter_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
+	"github.com/p2hacks2025/pre-12/backend/internal/textfilter"
+)
+
+func TestPostReview_TextFilterReject(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "構図は面白いけど、正直 ｷﾓｲ って思いました",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Code    apierror.Code         `json:"code"`
+		Details []apierror.FieldError `json:"details"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Code != apierror.CodeInappropriateText || len(resp.Details) != 1 || resp.Details[0].Field != "comment" {
+		t.Fatalf("expected field=comment, got %v", resp)
+	}
+}
+
+func TestPostReview_TextFilterMask(t *testing.T) {
+	r := setupTestRouter(withReview)
+
+	testServer.TextFilterMode = textfilter.ModeMask
+	t.Cleanup(func() { testServer.TextFilterMode = textfilter.ModeReject })
+
+	userA := createTestUser(t)
+	userB := createTestUser(t)
+	workA := createTestWork(t, userA)
+	workB := createTestWork(t, userB)
+	matchID := createTestMatch(t, userA, userB, workA, workB)
+
+	w := postJSON(r, http.MethodPost, "/review", CreateReviewRequest{
+		MatchID:    matchID,
+		FromUserID: userA,
+		Comment:    "構図は面白いけど、正直 ｷﾓｲ って思いました",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	ctx := context.Background()
+
+	reviewID, err := testServer.Reviews.FindID(ctx, matchID, userA)
+	if err != nil {
+		t.Fatalf("review not saved: %v", err)
+	}
+	review, err := testServer.Reviews.Get(ctx, reviewID)
+	if err != nil {
+		t.Fatalf("failed to get review: %v", err)
+	}
+	if strings.Contains(review.Comment, "ｷﾓｲ") || !strings.Contains(review.Comment, "***") {
+		t.Fatalf("expected masked comment, got %q", review.Comment)
+	}
+
+	// 伏せ字にした投稿はシステム通報としてモデレーションに回る
+	list, err := testServer.Reports.List(ctx, []string{service.ReportStatusOpen}, 100)
+	if err != nil {
+		t.Fatalf("failed to list reports: %v", err)
+	}
+	reports := 0
+	for _, rep := range list {
+		if rep.TargetType == service.ReportTargetReview && rep.TargetID == reviewID && rep.ReporterID == nil {
+			cleanupRow(t, "reports", rep.ID)
+			reports++
+		}
+	}
+	if reports != 1 {
+		t.Fatalf("expected 1 system report, got %d", reports)
+	}
+}
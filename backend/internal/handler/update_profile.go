@@ -2,78 +2,114 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"mime/multipart"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/storage"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
 )
 
-// UpdateMyProfile はユーザーのアイコン画像と自己紹介文を更新する
+// MaxUsernameLength は表示名の最大文字数
+const MaxUsernameLength = 30
+
+// UpdateProfileForm は POST /update-profile の multipart/form-data（送った項目だけ更新する）
+type UpdateProfileForm struct {
+	Bio      string                `form:"bio" binding:"max=500"`     // MaxBioLength
+	Username string                `form:"username" binding:"max=30"` // MaxUsernameLength
+	Icon     *multipart.FileHeader `form:"icon"`
+}
+
+// UpdateMyProfile はユーザーのアイコン画像・自己紹介文・表示名を更新する
 // multipart/form-data 形式で送信される想定
-func UpdateMyProfile(c *gin.Context) {
+func (s *Server) UpdateMyProfile(c *gin.Context) {
 	// クエリパラメータから user_id を取得
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
-	// multipart/form-data 形式のリクエストから文字列フィールド "bio" を取得
-	bio := c.PostForm("bio")
+	var form UpdateProfileForm
+	if !bindForm(c, &form) {
+		return
+	}
+	bio := form.Bio
+
+	// 表示名（ハンドルとは別で、重複してよい）
+	username := strings.TrimSpace(form.Username)
+
+	// 禁止語の検査（mask モードなら伏せ字にして保存する）
+	masked, ok := s.filterText(c,
+		textField{Name: "username", Value: &username},
+		textField{Name: "bio", Value: &bio},
+	)
+	if !ok {
+		return
+	}
 
-	// multipart/form-data からファイルフィールド "icon" を取得
-	fileHeader, err := c.FormFile("icon")
+	// ファイルフィールド "icon" があればアップロードする
+	fileHeader := form.Icon
 	var iconPath *string
-	if err == nil {
+	if fileHeader != nil {
 		// 保存パスをバケット名を含めて生成
 		newPath := "icons/" + userID + "/" + fileHeader.Filename
 
 		// Supabase Storage にアップロード（同じパスなら上書き）
-		if err := storage.UploadToSupabase(c, fileHeader, "icons", userID+"/"+fileHeader.Filename); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload icon"})
+		if err := s.Storage.UploadToSupabase(c.Request.Context(), fileHeader, "icons", userID+"/"+fileHeader.Filename); err != nil {
+			apierror.Abort(c, err)
 			return
 		}
 
 		iconPath = &newPath
 	}
 
-	// SQL 文の動的構築
-	query := `UPDATE public.users SET `
-	params := []interface{}{}
-	paramIdx := 1
-
-	if iconPath != nil {
-		query += `icon_path = $` + strconv.Itoa(paramIdx)
-		params = append(params, *iconPath)
-		paramIdx++
-	}
-
+	var update repository.ProfileUpdate
 	if bio != "" {
-		if len(params) > 0 {
-			query += `, `
-		}
-		query += `bio = $` + strconv.Itoa(paramIdx)
-		params = append(params, bio)
-		paramIdx++
+		update.Bio = &bio
+	}
+	if username != "" {
+		update.Username = &username
 	}
 
-	if len(params) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "nothing to update"})
+	if iconPath == nil && update.Bio == nil && update.Username == nil {
+		apierror.Abort(c, apierror.New(apierror.CodeNothingToUpdate))
 		return
 	}
 
-	query += ` WHERE id = $` + strconv.Itoa(paramIdx)
-	params = append(params, userID)
+	ctx := context.Background()
 
 	// DB 更新（上書き）
-	_, err = db.Pool.Exec(context.Background(), query, params...)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update profile"})
-		return
+	if iconPath != nil {
+		// 差し替え前のアイコンは更新後にストレージから消す
+		oldIconPath, err := s.Users.SetIconPath(ctx, userID, iconPath)
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Abort(c, err)
+			return
+		}
+		s.removeOldIcon(ctx, oldIconPath, iconPath)
 	}
 
+	if update.Bio != nil || update.Username != nil {
+		err := s.Users.UpdateProfile(ctx, userID, update)
+		if errors.Is(err, repository.ErrNotFound) {
+			apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Abort(c, err)
+			return
+		}
+	}
+
+	s.reportMaskedText(service.ReportTargetUser, userID, masked)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "profile updated",
 	})
@@ -8,8 +8,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-
-	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 func TestUpdateMyProfile_Success(t *testing.T) {
@@ -44,7 +42,7 @@ func TestUpdateMyProfile_Success(t *testing.T) {
 
 	// --- Storage cleanup ---
 	uploadedPath := userID + "/icon.png"
-	if err := storage.DeleteFromSupabase(context.Background(), "icons", uploadedPath); err != nil {
+	if err := testServer.Storage.DeleteFromSupabase(context.Background(), "icons", uploadedPath); err != nil {
 		t.Fatalf("failed to cleanup storage: %v", err)
 	}
 }
@@ -5,16 +5,16 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 type UserProfileResponse struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	IconURL  string `json:"icon_url"`
-	Bio      string `json:"bio"`
+	ID       string  `json:"id"`
+	Username string  `json:"username"`
+	Handle   *string `json:"handle"` // 未設定なら null
+	Email    string  `json:"email"`
+	IconURL  string  `json:"icon_url"`
+	Bio      string  `json:"bio"`
 }
 
 const (
@@ -22,53 +22,48 @@ const (
 	DefaultIconPath = "icons/default.png"
 )
 
-func GetMyProfile(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+func (s *Server) GetMyProfile(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-
-	var (
-		profile  UserProfileResponse
-		iconPath *string
-		bio      *string
-	)
+	profile, err := s.loadMyProfile(context.Background(), userID)
+	if err != nil {
+		apierror.Abort(c, apierror.New(apierror.CodeUserNotFound))
+		return
+	}
 
-	err := db.Pool.QueryRow(
-		ctx,
-		`SELECT id, username, email, icon_path, bio
-		 FROM public.users
-		 WHERE id = $1`,
-		userID,
-	).Scan(
-		&profile.ID,
-		&profile.Username,
-		&profile.Email,
-		&iconPath,
-		&bio,
-	)
+	c.JSON(http.StatusOK, profile)
+}
 
+// loadMyProfile は本人向けのプロフィール（email を含む）を読み込む
+func (s *Server) loadMyProfile(ctx context.Context, userID string) (UserProfileResponse, error) {
+	user, err := s.Users.Get(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
-		return
+		return UserProfileResponse{}, err
+	}
+
+	profile := UserProfileResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Handle:   user.Handle,
+		Email:    user.Email,
 	}
 
 	// icon_url（必ず返す）
-	if iconPath != nil {
-		profile.IconURL = lib.BuildPublicURL(*iconPath)
+	if user.IconPath != nil {
+		profile.IconURL = s.Storage.PublicURL(*user.IconPath)
 	} else {
-		profile.IconURL = lib.BuildPublicURL(DefaultIconPath)
+		profile.IconURL = s.Storage.PublicURL(DefaultIconPath)
 	}
 
 	// bio（必ず返す）
-	if bio != nil {
-		profile.Bio = *bio
+	if user.Bio != nil {
+		profile.Bio = *user.Bio
 	} else {
 		profile.Bio = DefaultBio
 	}
 
-	c.JSON(http.StatusOK, profile)
+	return profile, nil
 }
@@ -2,15 +2,12 @@ package handler
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
-
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
 )
 
 func TestGetMyProfileSuccess(t *testing.T) {
@@ -45,13 +42,7 @@ func TestGetMyProfileSuccess(t *testing.T) {
 		t.Fatal("user_id is empty")
 	}
 
-	t.Cleanup(func() {
-		db.Pool.Exec(
-			context.Background(),
-			"DELETE FROM public.users WHERE id=$1",
-			resp.UserID,
-		)
-	})
+	cleanupRow(t, "users", resp.UserID)
 
 	// --- プロフィール取得 ---
 	req := httptest.NewRequest(
@@ -3,54 +3,68 @@ package handler
 import (
 	"context"
 	"fmt"
+	"mime/multipart"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/storage"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/service"
 )
 
-func PostWork(c *gin.Context) {
-	// クライアントから送られてくる情報
-	userID := c.PostForm("user_id")
-	title := c.PostForm("title")
-	description := c.PostForm("description")
+// PostWorkForm は POST /work の multipart/form-data
+// タイトルは 100 文字、説明は 2000 文字まで
+type PostWorkForm struct {
+	UserID      string                `form:"user_id" binding:"required,uuid"`
+	Title       string                `form:"title" binding:"required,notblank,max=100"`
+	Description string                `form:"description" binding:"max=2000"`
+	Image       *multipart.FileHeader `form:"image" binding:"required"`
+}
 
-	if userID == "" || title == "" {
-		c.JSON(400, gin.H{"error": "user_id and title required"})
+func (s *Server) PostWork(c *gin.Context) {
+	// クライアントから送られてくる情報
+	var form PostWorkForm
+	if !bindForm(c, &form) {
 		return
 	}
+	userID := form.UserID
+	title := strings.TrimSpace(form.Title)
+	description := form.Description
 
-	// 画像ファイル取得
-	fileHeader, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(400, gin.H{"error": "image required"})
+	// 禁止語の検査（mask モードなら伏せ字にして保存する）
+	masked, ok := s.filterText(c,
+		textField{Name: "title", Value: &title},
+		textField{Name: "description", Value: &description},
+	)
+	if !ok {
 		return
 	}
 
+	fileHeader := form.Image
+
 	newPath := fmt.Sprintf("%s/%s", userID, fileHeader.Filename) // works バケット内のパス
 
 	//Supabase Storage にアップロード
-	if err := storage.UploadToSupabase(c, fileHeader, "works", newPath); err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+	if err := s.Storage.UploadToSupabase(c.Request.Context(), fileHeader, "works", newPath); err != nil {
+		apierror.Abort(c, err)
 		return
 	}
 
 	workPath := "works/" + newPath
 
 	// ③ DB に保存（同じ user_id + image_path があれば上書き）
-	_, err = db.Pool.Exec(
-		context.Background(),
-		`INSERT INTO works (user_id, image_path, title, description)
-		 VALUES ($1, $2, $3, $4)
-		 ON CONFLICT (user_id, image_path)
-		 DO UPDATE SET title = EXCLUDED.title,
-		               description = EXCLUDED.description`,
-		userID, workPath, title, description,
-	)
+	workID, err := s.Works.Save(context.Background(), repository.Work{
+		UserID:      userID,
+		ImagePath:   &workPath,
+		Title:       title,
+		Description: &description,
+	})
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
 
+	s.reportMaskedText(service.ReportTargetWork, workID, masked)
+
 	c.JSON(201, gin.H{"message": "ok"})
 }
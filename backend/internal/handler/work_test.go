@@ -8,11 +8,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-
-	"github.com/p2hacks2025/pre-12/backend/internal/storage"
 )
 
 func TestPostWork_Success(t *testing.T) {
+	// Supabase Storage にもアップロードするので、実環境につなぐ postgres のときだけ動かす
 	r := setupTestRouter(withPostWork)
 
 	userID := createTestUser(t)
@@ -40,7 +39,7 @@ func TestPostWork_Success(t *testing.T) {
 	// --- Storage cleanup ---
 	// アップロードパスは PostWork と同じ計算方法
 	uploadedPath := userID + "/test.png"
-	if err := storage.DeleteFromSupabase(context.Background(), "works", uploadedPath); err != nil {
+	if err := testServer.Storage.DeleteFromSupabase(context.Background(), "works", uploadedPath); err != nil {
 		t.Fatalf("failed to cleanup storage: %v", err)
 	}
 }
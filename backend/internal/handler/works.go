@@ -7,8 +7,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
-	"github.com/p2hacks2025/pre-12/backend/internal/lib"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
 )
 
 // WorkResponse は Flutter に返す作品情報の構造体
@@ -24,39 +23,20 @@ type WorkResponse struct {
 }
 
 // GetWorks はホーム画面用に未スワイプ作品をランダムに返す（高速版）
-func GetWorks(c *gin.Context) {
-	userID := c.Query("user_id")
-	if userID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+func (s *Server) GetWorks(c *gin.Context) {
+	userID, ok := queryUUID(c, "user_id")
+	if !ok {
 		return
 	}
 
 	ctx := context.Background()
 
-	// 1. 未スワイプ作品IDを取得
-	idRows, err := db.Pool.Query(ctx, `
-        SELECT w.id
-        FROM public.works w
-        LEFT JOIN public.swipes s
-            ON s.from_user_id = $1 AND s.to_work_id = w.id
-        WHERE w.user_id <> $1
-            AND s.id IS NULL
-    `, userID)
+	// 1. 未スワイプ作品IDを取得（非表示の作品と停止中ユーザーの作品は除く）
+	workIDs, err := s.Works.UnswipedIDs(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
-	defer idRows.Close()
-
-	var workIDs []string
-	for idRows.Next() {
-		var id string
-		if err := idRows.Scan(&id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		workIDs = append(workIDs, id)
-	}
 
 	if len(workIDs) == 0 {
 		// 未スワイプ作品がない場合
@@ -74,54 +54,46 @@ func GetWorks(c *gin.Context) {
 	}
 
 	// 3. 選ばれたIDで作品情報をまとめて取得
-	query := `
-		SELECT w.id, w.user_id, u.username, u.icon_path, w.image_path, w.title, w.description, w.created_at
-		FROM public.works w
-		JOIN public.users u ON u.id = w.user_id
-		WHERE w.id = ANY($1)
-	`
-	rows, err := db.Pool.Query(ctx, query, selectedIDs)
+	feed, err := s.Works.Feed(ctx, selectedIDs)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.Abort(c, err)
 		return
 	}
-	defer rows.Close()
 
 	var works []WorkResponse
-	for rows.Next() {
-		var w WorkResponse
-		var iconPath, imagePath, description *string
-		var createdAt time.Time
-		if err := rows.Scan(&w.ID, &w.UserID, &w.Username, &iconPath, &imagePath, &w.Title, &description, &createdAt); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	for _, work := range feed {
+		w := WorkResponse{
+			ID:       work.ID,
+			UserID:   work.UserID,
+			Username: work.Username,
+			Title:    work.Title,
 		}
 
 		const DefaultIconPath = "icons/default.png"
 		const DefaultImagePath = "images/default.png"
 
 		// description はNULLなら空文字にする
-		if description != nil {
-			w.Description = *description
+		if work.Description != nil {
+			w.Description = *work.Description
 		} else {
 			w.Description = ""
 		}
 
 		// icon_url（必ず返す）
-		if iconPath != nil {
-			w.IconURL = lib.BuildPublicURL(*iconPath)
+		if work.IconPath != nil {
+			w.IconURL = s.Storage.PublicURL(*work.IconPath)
 		} else {
-			w.IconURL = lib.BuildPublicURL(DefaultIconPath)
+			w.IconURL = s.Storage.PublicURL(DefaultIconPath)
 		}
 
 		// image_url（必ず返す）
-		if imagePath != nil {
-			w.ImageURL = lib.BuildPublicURL(*imagePath)
+		if work.ImagePath != nil {
+			w.ImageURL = s.Storage.PublicURL(*work.ImagePath)
 		} else {
-			w.ImageURL = lib.BuildPublicURL(DefaultImagePath)
+			w.ImageURL = s.Storage.PublicURL(DefaultImagePath)
 		}
 
-		w.CreatedAt = createdAt.Format(time.RFC3339)
+		w.CreatedAt = work.CreatedAt.Format(time.RFC3339)
 
 		works = append(works, w)
 	}
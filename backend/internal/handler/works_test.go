@@ -22,7 +22,7 @@ func TestGetWorks_CountOnly(t *testing.T) {
 
 	// --- workB1 をスワイプ済みにする ---
 	workToSwipe := createTestWork(t, userB)
-	createTestSwipe(t, userA, workToSwipe, userB, true)
+	createTestSwipe(t, userA, workToSwipe, true)
 
 	// --- API 呼び出し ---
 	req := httptest.NewRequest(http.MethodGet, "/works?user_id="+userA, nil)
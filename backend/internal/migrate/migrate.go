@@ -0,0 +1,148 @@
+// Package migrate は supabase/migrations の SQL を順番に適用し、適用済みのバージョンを記録する
+// supabase CLI がない環境（素の Postgres）でもスキーマを作れるようにする
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Migration は 1 つのマイグレーション（migrations/ のファイル 1 つ）
+type Migration struct {
+	Version  string // ファイル名の先頭のタイムスタンプ
+	Name     string
+	Up       string
+	Down     string // rollbacks/ に同名のファイルがなければ空
+	Checksum string // Up の sha256
+}
+
+// Applied は schema_migrations に記録された適用済みのマイグレーション
+type Applied struct {
+	Version   string
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+
+// Load は fsys の migrations/ と rollbacks/ を読み、バージョン順に並べて返す
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := map[string]string{}
+	names := map[string]bool{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: invalid migration file name: %s", e.Name())
+		}
+		if prev, ok := seen[match[1]]; ok {
+			return nil, fmt.Errorf("migrate: duplicate version %s: %s and %s", match[1], prev, e.Name())
+		}
+		seen[match[1]] = e.Name()
+		names[e.Name()] = true
+
+		up, err := fs.ReadFile(fsys, path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		down, err := fs.ReadFile(fsys, path.Join("rollbacks", e.Name()))
+		if err != nil && !isNotExist(err) {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(up)
+		migrations = append(migrations, Migration{
+			Version:  match[1],
+			Name:     match[2],
+			Up:       string(up),
+			Down:     string(down),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	// rollbacks/ だけにあるファイルは名前の付け間違い
+	rollbacks, err := fs.ReadDir(fsys, "rollbacks")
+	if err != nil && !isNotExist(err) {
+		return nil, err
+	}
+	for _, e := range rollbacks {
+		if !names[e.Name()] {
+			return nil, fmt.Errorf("migrate: rollback without migration: %s", e.Name())
+		}
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// Report はファイルと適用済みの記録を突き合わせた結果
+type Report struct {
+	Pending []Migration // まだ適用していない
+	Changed []Migration // 適用後にファイルが書き換えられた
+	Missing []Applied   // 適用済みだがファイルがない
+}
+
+// Diff は migrations と applied を突き合わせる
+func Diff(migrations []Migration, applied []Applied) Report {
+	byVersion := make(map[string]Applied, len(applied))
+	for _, a := range applied {
+		byVersion[a.Version] = a
+	}
+
+	var r Report
+	known := make(map[string]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+		a, ok := byVersion[m.Version]
+		switch {
+		case !ok:
+			r.Pending = append(r.Pending, m)
+		case a.Checksum != m.Checksum:
+			r.Changed = append(r.Changed, m)
+		}
+	}
+	for _, a := range applied {
+		if !known[a.Version] {
+			r.Missing = append(r.Missing, a)
+		}
+	}
+	return r
+}
+
+// Drift は適用済みのスキーマとファイルが食い違っていればエラーを返す
+// 未適用のマイグレーションがあるだけならドリフトとはみなさない
+func (r Report) Drift() error {
+	if len(r.Changed) == 0 && len(r.Missing) == 0 {
+		return nil
+	}
+
+	var parts []string
+	for _, m := range r.Changed {
+		parts = append(parts, fmt.Sprintf("%s_%s was modified after being applied", m.Version, m.Name))
+	}
+	for _, a := range r.Missing {
+		parts = append(parts, fmt.Sprintf("%s_%s is applied but has no migration file", a.Version, a.Name))
+	}
+	return fmt.Errorf("migrate: schema drift: %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,88 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/p2hacks2025/pre-12/backend/supabase"
+)
+
+func TestLoadSortsAndPairsRollbacks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20240102000000_add_b.sql": {Data: []byte("create table b ();")},
+		"migrations/20240101000000_add_a.sql": {Data: []byte("create table a ();")},
+		"rollbacks/20240101000000_add_a.sql":  {Data: []byte("drop table a;")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != "20240101000000" || migrations[1].Name != "add_b" {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+	if migrations[0].Down != "drop table a;" || migrations[1].Down != "" {
+		t.Fatalf("rollbacks not paired: %+v", migrations)
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"bad name": {
+			"migrations/add_a.sql": {Data: []byte("")},
+		},
+		"duplicate version": {
+			"migrations/20240101000000_a.sql": {Data: []byte("")},
+			"migrations/20240101000000_b.sql": {Data: []byte("")},
+		},
+		"orphan rollback": {
+			"migrations/20240101000000_a.sql": {Data: []byte("")},
+			"rollbacks/20240101000000_b.sql":  {Data: []byte("")},
+		},
+	}
+	for name, fsys := range cases {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	migrations := []Migration{
+		{Version: "1", Name: "a", Checksum: "x"},
+		{Version: "2", Name: "b", Checksum: "y"},
+		{Version: "3", Name: "c", Checksum: "z"},
+	}
+
+	report := Diff(migrations, []Applied{{Version: "1", Checksum: "x"}})
+	if len(report.Pending) != 2 || report.Drift() != nil {
+		t.Fatalf("unapplied migrations are not drift: %+v", report)
+	}
+
+	report = Diff(migrations, []Applied{
+		{Version: "1", Checksum: "x"},
+		{Version: "2", Checksum: "changed"},
+		{Version: "9", Name: "removed", Checksum: "w"},
+	})
+	err := report.Drift()
+	if err == nil || !strings.Contains(err.Error(), "2_b") || !strings.Contains(err.Error(), "9_removed") {
+		t.Fatalf("expected drift for 2_b and 9_removed, got %v", err)
+	}
+}
+
+// 埋め込んだマイグレーションはすべて取り消せる
+func TestEmbeddedMigrationsHaveRollbacks(t *testing.T) {
+	migrations, err := Load(supabase.Files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for _, m := range migrations {
+		if strings.TrimSpace(m.Down) == "" {
+			t.Errorf("%s_%s has no rollback", m.Version, m.Name)
+		}
+	}
+}
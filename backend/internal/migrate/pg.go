@@ -0,0 +1,207 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 複数のインスタンスが同時に起動しても 1 つずつ適用するためのアドバイザリロックのキー
+const lockKey = 7320191209
+
+// Migrator は public.schema_migrations に適用済みのバージョンを記録しながらマイグレーションを実行する
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+func NewMigrator(pool *pgxpool.Pool, migrations []Migration) *Migrator {
+	return &Migrator{pool: pool, migrations: migrations}
+}
+
+// Migrations は読み込んだマイグレーション（バージョン順）
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status は適用済みの記録とファイルを突き合わせる
+func (m *Migrator) Status(ctx context.Context) (Report, []Applied, error) {
+	if err := ensureTable(ctx, m.pool); err != nil {
+		return Report{}, nil, err
+	}
+	applied, err := listApplied(ctx, m.pool)
+	if err != nil {
+		return Report{}, nil, err
+	}
+	return Diff(m.migrations, applied), applied, nil
+}
+
+// Up は未適用のマイグレーションを古い順に 1 つずつトランザクションで適用する
+// ドリフトがあるときは何もしない
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := listApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		report := Diff(m.migrations, applied)
+		if err := report.Drift(); err != nil {
+			return err
+		}
+
+		for _, mig := range report.Pending {
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `
+					INSERT INTO public.schema_migrations (version, name, checksum)
+					VALUES ($1, $2, $3)
+				`, mig.Version, mig.Name, mig.Checksum)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migrate: %s_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down は新しいものから steps 個のマイグレーションを rollbacks/ の SQL で取り消す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	byVersion := make(map[string]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := listApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := Diff(m.migrations, applied).Drift(); err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := byVersion[applied[i].Version]
+			if mig.Down == "" {
+				return fmt.Errorf("migrate: %s_%s has no rollback", mig.Version, mig.Name)
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migrate: rollback %s_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline は version までのマイグレーションを実行せずに適用済みとして記録する
+// supabase CLI で作ったデータベースをこの仕組みに移すときに使う
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			tag, err := conn.Exec(ctx, `
+				INSERT INTO public.schema_migrations (version, name, checksum)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (version) DO NOTHING
+			`, mig.Version, mig.Name, mig.Checksum)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() > 0 {
+				done = append(done, mig)
+			}
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Check はドリフトがあればエラーを返し、未適用のマイグレーションを返す（起動時の確認用）
+func (m *Migrator) Check(ctx context.Context) ([]Migration, error) {
+	report, _, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return report.Pending, report.Drift()
+}
+
+// querier は *pgxpool.Pool と *pgxpool.Conn の共通部分
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func ensureTable(ctx context.Context, q querier) error {
+	_, err := q.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS public.schema_migrations (
+		  version text primary key,
+		  name text not null,
+		  checksum text not null,
+		  applied_at timestamp with time zone not null default now()
+		)
+	`)
+	return err
+}
+
+func listApplied(ctx context.Context, q querier) ([]Applied, error) {
+	rows, err := q.Query(ctx, `
+		SELECT version, name, checksum, applied_at
+		FROM public.schema_migrations
+		ORDER BY version
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []Applied
+	for rows.Next() {
+		var a Applied
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = 500 * time.Millisecond
+)
+
+// Dispatcher はユーザーの全端末へプッシュ通知を配る
+// 一時的な失敗は指数バックオフで再送し、無効なトークンは TokenStore から削除する
+type Dispatcher struct {
+	Pusher Pusher
+	Tokens TokenStore
+
+	// MaxAttempts は 1 端末あたりの最大送信回数（0 なら既定値）
+	MaxAttempts int
+	// Backoff は最初の再送までの待ち時間で、再送ごとに倍になる（0 なら既定値）
+	Backoff time.Duration
+}
+
+func NewDispatcher(pusher Pusher, tokens TokenStore) *Dispatcher {
+	return &Dispatcher{Pusher: pusher, Tokens: tokens}
+}
+
+// Notification は端末に依らない通知内容
+type Notification struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// NotifyUser は userID の全端末に通知を送る
+// 一部の端末で失敗しても残りには送り、失敗をまとめて返す
+func (d *Dispatcher) NotifyUser(ctx context.Context, userID string, n Notification) error {
+	devices, err := d.Tokens.DevicesForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, dev := range devices {
+		msg := Message{
+			Token:    dev.Token,
+			Platform: dev.Platform,
+			Title:    n.Title,
+			Body:     n.Body,
+			Data:     n.Data,
+		}
+
+		err := d.pushWithRetry(ctx, msg)
+		if errors.Is(err, ErrInvalidToken) {
+			if err := d.Tokens.RemoveToken(ctx, dev.Token); err != nil {
+				errs = append(errs, err)
+			} else {
+				log.Printf("notify: removed invalid token %s", shortToken(dev.Token))
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, errPush(dev.Token, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) pushWithRetry(ctx context.Context, msg Message) error {
+	attempts := d.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+	backoff := d.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		err = d.Pusher.Push(ctx, msg)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
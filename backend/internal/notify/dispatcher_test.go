@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryTokenStore はテスト用のメモリ上の TokenStore
+type memoryTokenStore struct {
+	mu      sync.Mutex
+	devices map[string][]Device
+}
+
+func newMemoryTokenStore() *memoryTokenStore {
+	return &memoryTokenStore{devices: make(map[string][]Device)}
+}
+
+// Register は userID に端末を紐づける（同じトークンは付け替える）
+func (s *memoryTokenStore) Register(userID string, d Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(d.Token)
+	s.devices[userID] = append(s.devices[userID], d)
+}
+
+func (s *memoryTokenStore) DevicesForUser(_ context.Context, userID string) ([]Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Device(nil), s.devices[userID]...), nil
+}
+
+func (s *memoryTokenStore) RemoveToken(_ context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(token)
+	return nil
+}
+
+func (s *memoryTokenStore) removeLocked(token string) {
+	for userID, devices := range s.devices {
+		kept := devices[:0]
+		for _, d := range devices {
+			if d.Token != token {
+				kept = append(kept, d)
+			}
+		}
+		s.devices[userID] = kept
+	}
+}
+
+func newTestDispatcher() (*Dispatcher, *FakePusher, *memoryTokenStore) {
+	pusher := NewFakePusher()
+	tokens := newMemoryTokenStore()
+	d := NewDispatcher(pusher, tokens)
+	d.Backoff = time.Millisecond
+	return d, pusher, tokens
+}
+
+func TestDispatcherSendsToAllDevices(t *testing.T) {
+	d, pusher, tokens := newTestDispatcher()
+
+	tokens.Register("alice", Device{Token: "ios-token", Platform: PlatformIOS})
+	tokens.Register("alice", Device{Token: "android-token", Platform: PlatformAndroid})
+	tokens.Register("bob", Device{Token: "bob-token", Platform: PlatformIOS})
+
+	err := d.NotifyUser(context.Background(), "alice", Notification{
+		Title: "マッチしました",
+		Body:  "bob さんとマッチしました",
+		Data:  map[string]string{"type": "match.created"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent := pusher.Sent()
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(sent))
+	}
+	for _, m := range sent {
+		if m.Token == "bob-token" {
+			t.Fatal("bob should not receive alice's notification")
+		}
+		if m.Title != "マッチしました" || m.Data["type"] != "match.created" {
+			t.Fatalf("unexpected message: %+v", m)
+		}
+	}
+}
+
+func TestDispatcherRetriesTransientFailures(t *testing.T) {
+	d, pusher, tokens := newTestDispatcher()
+
+	tokens.Register("alice", Device{Token: "flaky", Platform: PlatformAndroid})
+	pusher.FailNext("flaky", 2)
+
+	if err := d.NotifyUser(context.Background(), "alice", Notification{Title: "t", Body: "b"}); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if len(pusher.Sent()) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(pusher.Sent()))
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	d, pusher, tokens := newTestDispatcher()
+
+	tokens.Register("alice", Device{Token: "down", Platform: PlatformAndroid})
+	pusher.FailNext("down", defaultMaxAttempts)
+
+	if err := d.NotifyUser(context.Background(), "alice", Notification{Title: "t", Body: "b"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// 一時的な失敗ではトークンを消さない
+	devices, _ := tokens.DevicesForUser(context.Background(), "alice")
+	if len(devices) != 1 {
+		t.Fatalf("expected token to be kept, got %d devices", len(devices))
+	}
+}
+
+func TestDispatcherRemovesInvalidTokens(t *testing.T) {
+	d, pusher, tokens := newTestDispatcher()
+
+	tokens.Register("alice", Device{Token: "stale", Platform: PlatformIOS})
+	tokens.Register("alice", Device{Token: "fresh", Platform: PlatformIOS})
+	pusher.MarkInvalid("stale")
+
+	if err := d.NotifyUser(context.Background(), "alice", Notification{Title: "t", Body: "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	devices, _ := tokens.DevicesForUser(context.Background(), "alice")
+	if len(devices) != 1 || devices[0].Token != "fresh" {
+		t.Fatalf("expected only fresh token to remain, got %+v", devices)
+	}
+	if sent := pusher.Sent(); len(sent) != 1 || sent[0].Token != "fresh" {
+		t.Fatalf("unexpected sent messages: %+v", sent)
+	}
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+var errFakeUnavailable = errors.New("notify(fake): service unavailable")
+
+// FakePusher は送信内容を記録するだけの Pusher 実装
+// ローカル開発やテストで FCM/APNs なしに通知の流れを確認するために使う
+type FakePusher struct {
+	// Log が true なら送信内容をログに出す
+	Log bool
+
+	mu            sync.Mutex
+	sent          []Message
+	invalidTokens map[string]bool
+	failures      map[string]int
+}
+
+func NewFakePusher() *FakePusher {
+	return &FakePusher{
+		invalidTokens: make(map[string]bool),
+		failures:      make(map[string]int),
+	}
+}
+
+// MarkInvalid は token への送信を ErrInvalidToken で失敗させる
+func (p *FakePusher) MarkInvalid(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invalidTokens[token] = true
+}
+
+// FailNext は token への次の n 回の送信を一時的な失敗にする
+func (p *FakePusher) FailNext(token string, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures[token] = n
+}
+
+func (p *FakePusher) Push(_ context.Context, msg Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.invalidTokens[msg.Token] {
+		return ErrInvalidToken
+	}
+	if p.failures[msg.Token] > 0 {
+		p.failures[msg.Token]--
+		return Retryable(errFakeUnavailable)
+	}
+
+	p.sent = append(p.sent, msg)
+	if p.Log {
+		log.Printf("notify(fake): to=%s title=%q body=%q data=%v", shortToken(msg.Token), msg.Title, msg.Body, msg.Data)
+	}
+	return nil
+}
+
+// Sent は記録した送信内容のコピーを返す
+func (p *FakePusher) Sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.sent...)
+}
+
+// Reset は記録をすべて消す
+func (p *FakePusher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent = nil
+	p.invalidTokens = make(map[string]bool)
+	p.failures = make(map[string]int)
+}
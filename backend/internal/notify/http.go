@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPPusher は FCM HTTP v1 形式の JSON を送る Pusher 実装
+// iOS 向けには apns ブロックも付けるので、FCM 経由の APNs 配信や同形式のゲートウェイに使える
+type HTTPPusher struct {
+	Endpoint  string
+	ServerKey string
+	Client    *http.Client
+}
+
+func NewHTTPPusher(endpoint, serverKey string) *HTTPPusher {
+	return &HTTPPusher{Endpoint: endpoint, ServerKey: serverKey, Client: http.DefaultClient}
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	APNS         *apnsConfig       `json:"apns,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type apnsConfig struct {
+	Payload apnsPayload `json:"payload"`
+}
+
+type apnsPayload struct {
+	APS apsDictionary `json:"aps"`
+}
+
+type apsDictionary struct {
+	Alert fcmNotification `json:"alert"`
+	Sound string          `json:"sound"`
+}
+
+func (p *HTTPPusher) Push(ctx context.Context, msg Message) error {
+	body := fcmRequest{
+		Message: fcmMessage{
+			Token:        msg.Token,
+			Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+			Data:         msg.Data,
+		},
+	}
+	if msg.Platform == PlatformIOS {
+		body.Message.APNS = &apnsConfig{
+			Payload: apnsPayload{APS: apsDictionary{
+				Alert: fcmNotification{Title: msg.Title, Body: msg.Body},
+				Sound: "default",
+			}},
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.ServerKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		// ネットワークエラーは再送する
+		return Retryable(err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		// FCM は UNREGISTERED を 404、APNs は Unregistered を 410 で返す
+		return ErrInvalidToken
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return Retryable(fmt.Errorf("push failed: %s", res.Status))
+	default:
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("push failed: %s - %s", res.Status, string(resBody))
+	}
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPPusherSendsFCMShapedPayload(t *testing.T) {
+	var got fcmRequest
+	var auth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := NewHTTPPusher(srv.URL, "secret")
+	err := p.Push(context.Background(), Message{
+		Token:    "device-1",
+		Platform: PlatformIOS,
+		Title:    "キラットが届きました",
+		Body:     "レビューが届きました",
+		Data:     map[string]string{"review_id": "r1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if auth != "Bearer secret" {
+		t.Fatalf("unexpected authorization header: %q", auth)
+	}
+	if got.Message.Token != "device-1" || got.Message.Notification.Title != "キラットが届きました" {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	if got.Message.Data["review_id"] != "r1" {
+		t.Fatalf("unexpected data: %+v", got.Message.Data)
+	}
+	if got.Message.APNS == nil || got.Message.APNS.Payload.APS.Alert.Body != "レビューが届きました" {
+		t.Fatalf("expected apns block for ios: %+v", got.Message.APNS)
+	}
+}
+
+func TestHTTPPusherMapsStatusCodes(t *testing.T) {
+	tests := []struct {
+		status    int
+		invalid   bool
+		retryable bool
+	}{
+		{http.StatusOK, false, false},
+		{http.StatusNotFound, true, false},
+		{http.StatusGone, true, false},
+		{http.StatusTooManyRequests, false, true},
+		{http.StatusServiceUnavailable, false, true},
+		{http.StatusBadRequest, false, false},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+
+		err := NewHTTPPusher(srv.URL, "key").Push(context.Background(), Message{Token: "t", Platform: PlatformAndroid})
+		srv.Close()
+
+		if got := errors.Is(err, ErrInvalidToken); got != tt.invalid {
+			t.Errorf("status %d: invalid=%v, want %v (err=%v)", tt.status, got, tt.invalid, err)
+		}
+		if got := IsRetryable(err); got != tt.retryable {
+			t.Errorf("status %d: retryable=%v, want %v (err=%v)", tt.status, got, tt.retryable, err)
+		}
+		if tt.status == http.StatusBadRequest && err == nil {
+			t.Errorf("status %d: expected error", tt.status)
+		}
+	}
+}
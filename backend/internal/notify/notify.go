@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 端末のプラットフォーム
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWeb     = "web"
+)
+
+// IsPlatform はプラットフォーム名として有効か判定する
+func IsPlatform(p string) bool {
+	return p == PlatformIOS || p == PlatformAndroid || p == PlatformWeb
+}
+
+// ErrInvalidToken はプッシュ先のトークンが無効（アンインストール等）であることを表す
+// このエラーを受け取ったトークンは削除してよい
+var ErrInvalidToken = errors.New("notify: invalid device token")
+
+// Message は 1 端末に送るプッシュ通知
+type Message struct {
+	Token    string            `json:"token"`
+	Platform string            `json:"platform"`
+	Title    string            `json:"title"`
+	Body     string            `json:"body"`
+	Data     map[string]string `json:"data,omitempty"`
+}
+
+// Pusher はプッシュ通知の送信先（FCM/APNs など）
+type Pusher interface {
+	Push(ctx context.Context, msg Message) error
+}
+
+// Device は登録済みの端末
+type Device struct {
+	Token    string
+	Platform string
+}
+
+// TokenStore は端末トークンの保存先
+type TokenStore interface {
+	DevicesForUser(ctx context.Context, userID string) ([]Device, error)
+	RemoveToken(ctx context.Context, token string) error
+}
+
+// retryableError は時間をおけば成功しうる失敗（5xx, 429 など）
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Retryable は err を再送対象のエラーとして包む
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+// IsRetryable は err が再送対象か判定する
+func IsRetryable(err error) bool {
+	var re *retryableError
+	return errors.As(err, &re)
+}
+
+// errPush は送信失敗をまとめるときの接頭辞
+func errPush(token string, err error) error {
+	return fmt.Errorf("push to %s: %w", shortToken(token), err)
+}
+
+// shortToken はログ用にトークンを短くする
+func shortToken(token string) string {
+	if len(token) <= 8 {
+		return token
+	}
+	return token[:8] + "..."
+}
@@ -0,0 +1,202 @@
+package quality
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+// Mode は品質チェックに引っかかったレビューの扱い
+type Mode string
+
+const (
+	ModeReject Mode = "reject" // 投稿を拒否する
+	ModeFlag   Mode = "flag"   // 投稿は受け付け、フラグを付けて残す
+)
+
+// アプリが表示を出し分けるためのエラーコード
+const (
+	CodeTooShort           = "too_short"
+	CodeRepeatedCharacters = "repeated_characters"
+	CodeBoilerplate        = "boilerplate"
+	CodeDuplicate          = "duplicate"
+)
+
+// しきい値
+const (
+	MinSectionLength    = 5    // 観点ごとの本文の最小文字数
+	MinCommentLength    = 10   // comment だけで送る場合の最小文字数
+	MaxRepeatedRun      = 5    // 同じ文字がこれ以上続くと連打とみなす
+	MinCharDiversity    = 0.25 // 異なる文字の割合がこれ未満だと連打とみなす
+	DuplicateSimilarity = 0.85 // 過去のレビューとの類似度がこれ以上だとコピペとみなす
+)
+
+// boilerplatePhrases は中身のない定型句（これらを除くと何も残らないレビューは定型文とみなす）
+var boilerplatePhrases = []string{
+	"いいね", "いい", "良い", "よい", "すごい", "凄い", "すごく", "すてき", "素敵", "最高",
+	"かわいい", "可愛い", "かっこいい", "格好いい", "きれい", "綺麗", "よかった", "良かった",
+	"好き", "とても", "めっちゃ", "本当に", "ほんとに", "作品", "です", "でした", "ですね",
+	"ました", "と思います", "思います", "ありがとう", "ございます",
+	"great", "good", "nice", "cool", "awesome", "amazing", "beautiful", "love", "like",
+	"work", "very", "so", "really", "the", "this", "is", "it", "lgtm", "thanks",
+}
+
+func init() {
+	// 長い句から取り除く
+	sort.SliceStable(boilerplatePhrases, func(i, j int) bool {
+		return utf8.RuneCountInString(boilerplatePhrases[i]) > utf8.RuneCountInString(boilerplatePhrases[j])
+	})
+}
+
+// Issue は品質チェックで見つかった問題
+type Issue struct {
+	Code    string `json:"code"`
+	Aspect  string `json:"aspect,omitempty"` // comment 全体の問題なら空
+	Message string `json:"message"`
+}
+
+// Codes は issues のコードを重複なしで返す（reviews.quality_flags に保存する）
+func Codes(issues []Issue) []string {
+	codes := []string{}
+	seen := map[string]bool{}
+	for _, is := range issues {
+		if !seen[is.Code] {
+			seen[is.Code] = true
+			codes = append(codes, is.Code)
+		}
+	}
+	return codes
+}
+
+// CheckReview はレビューの品質を検査する
+// previous には同じユーザーが過去に送ったレビューの comment を渡す
+func CheckReview(comment string, sections []guideline.Section, previous []string) []Issue {
+	var issues []Issue
+
+	if len(sections) > 0 {
+		for _, s := range sections {
+			body := strings.TrimSpace(s.Body)
+			if body == "" {
+				continue
+			}
+			issues = append(issues, checkText(body, MinSectionLength, s.Aspect)...)
+		}
+	} else {
+		issues = append(issues, checkText(strings.TrimSpace(comment), MinCommentLength, "")...)
+	}
+
+	if IsDuplicate(comment, previous) {
+		issues = append(issues, Issue{Code: CodeDuplicate, Message: "same as one of your previous reviews"})
+	}
+
+	return issues
+}
+
+func checkText(text string, minLength int, aspect string) []Issue {
+	var issues []Issue
+
+	if utf8.RuneCountInString(text) < minLength {
+		issues = append(issues, Issue{Code: CodeTooShort, Aspect: aspect, Message: "too short"})
+		// 短すぎる本文は他のチェックをしても意味がない
+		return issues
+	}
+	if IsRepetitive(text) {
+		issues = append(issues, Issue{Code: CodeRepeatedCharacters, Aspect: aspect, Message: "too many repeated characters"})
+	}
+	if IsBoilerplate(text) {
+		issues = append(issues, Issue{Code: CodeBoilerplate, Aspect: aspect, Message: "only boilerplate phrases"})
+	}
+	return issues
+}
+
+// normalize は比較用に小文字化し、空白・記号・句読点を取り除く
+func normalize(s string) []rune {
+	out := make([]rune, 0, len(s))
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// IsRepetitive は同じ文字の連打や、ごく少数の文字の繰り返しを検出する
+func IsRepetitive(text string) bool {
+	runes := normalize(text)
+	if len(runes) == 0 {
+		return false
+	}
+
+	run := 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i] == runes[i-1] {
+			run++
+			if run >= MaxRepeatedRun {
+				return true
+			}
+		} else {
+			run = 1
+		}
+	}
+
+	distinct := map[rune]bool{}
+	for _, r := range runes {
+		distinct[r] = true
+	}
+	return len(runes) >= MinCommentLength && float64(len(distinct))/float64(len(runes)) < MinCharDiversity
+}
+
+// IsBoilerplate は定型句を除くとほとんど何も残らない本文を検出する
+func IsBoilerplate(text string) bool {
+	rest := string(normalize(text))
+	if rest == "" {
+		return false
+	}
+	for _, p := range boilerplatePhrases {
+		rest = strings.ReplaceAll(rest, p, "")
+	}
+	return utf8.RuneCountInString(rest) < 3
+}
+
+// IsDuplicate は過去のレビューとほぼ同じ本文かを判定する
+func IsDuplicate(text string, previous []string) bool {
+	a := bigrams(normalize(text))
+	if len(a) == 0 {
+		return false
+	}
+	for _, p := range previous {
+		if similarity(a, bigrams(normalize(p))) >= DuplicateSimilarity {
+			return true
+		}
+	}
+	return false
+}
+
+func bigrams(runes []rune) map[string]bool {
+	set := map[string]bool{}
+	if len(runes) == 1 {
+		set[string(runes)] = true
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		set[string(runes[i:i+2])] = true
+	}
+	return set
+}
+
+// similarity は bigram の Jaccard 係数
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for k := range a {
+		if b[k] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
@@ -0,0 +1,82 @@
+package quality
+
+import (
+	"testing"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+func hasCode(issues []Issue, code string) bool {
+	for _, is := range issues {
+		if is.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckReviewAcceptsThoughtfulReview(t *testing.T) {
+	sections := []guideline.Section{
+		{Aspect: guideline.AspectFirstImpression, Body: "透明感があって静かな印象"},
+		{Aspect: guideline.AspectObservation, Body: "窓からの光の入り方がきれい"},
+	}
+
+	if issues := CheckReview("", sections, nil); len(issues) != 0 {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestCheckReviewDetectsLowQuality(t *testing.T) {
+	cases := []struct {
+		name    string
+		comment string
+		code    string
+	}{
+		{"empty", "", CodeTooShort},
+		{"short", "いいね", CodeTooShort},
+		{"repeated run", "すごーーーーーーい！！", CodeRepeatedCharacters},
+		{"low diversity", "abababababababab", CodeRepeatedCharacters},
+		{"boilerplate ja", "とても素敵な作品ですね！いいね！", CodeBoilerplate},
+		{"boilerplate en", "Great work!! Really nice.", CodeBoilerplate},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues := CheckReview(tc.comment, nil, nil)
+			if !hasCode(issues, tc.code) {
+				t.Fatalf("expected %s, got %+v", tc.code, issues)
+			}
+		})
+	}
+}
+
+func TestCheckReviewSectionIssuesHaveAspect(t *testing.T) {
+	sections := []guideline.Section{
+		{Aspect: guideline.AspectFirstImpression, Body: "透明感があって静かな印象"},
+		{Aspect: guideline.AspectObservation, Body: "色"},
+	}
+
+	issues := CheckReview("", sections, nil)
+	if len(issues) != 1 || issues[0].Code != CodeTooShort || issues[0].Aspect != guideline.AspectObservation {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestIsDuplicate(t *testing.T) {
+	previous := []string{"夕焼けの色の重ね方がとても丁寧で、空気の温度まで伝わってきました。"}
+
+	if !IsDuplicate("夕焼けの色の重ね方がとても丁寧で、空気の温度まで伝わってきました！", previous) {
+		t.Fatal("expected near-identical text to be duplicate")
+	}
+	if IsDuplicate("人物の表情が柔らかく、背景のぼかしとよく合っています。", previous) {
+		t.Fatal("expected different text not to be duplicate")
+	}
+}
+
+func TestCodes(t *testing.T) {
+	issues := []Issue{{Code: CodeTooShort}, {Code: CodeBoilerplate}, {Code: CodeTooShort}}
+	got := Codes(issues)
+	if len(got) != 2 || got[0] != CodeTooShort || got[1] != CodeBoilerplate {
+		t.Fatalf("unexpected codes: %v", got)
+	}
+}
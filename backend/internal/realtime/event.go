@@ -0,0 +1,63 @@
+package realtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+)
+
+// イベント種別
+const (
+	EventMatchCreated   = "match.created"
+	EventReviewReceived = "review.received"
+	EventReviewUnlocked = "review.unlocked"
+	EventReviewRead     = "review.read"
+	EventReviewReacted  = "review.reacted"
+	EventMessageCreated = "message.created"
+	EventMessageRead    = "message.read"
+)
+
+// Event はクライアントへ配信するイベント
+// UserID は配信先ユーザーで、そのユーザーの購読者にだけ届く
+type Event struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	UserID    string         `json:"user_id"`
+	Data      map[string]any `json:"data"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// Broker はイベントの配信と購読を行う
+type Broker interface {
+	// Publish は ev.UserID の購読者にイベントを配信する
+	Publish(ctx context.Context, ev Event) error
+	// Subscribe は userID 宛てのイベントを受け取るチャネルと購読解除関数を返す
+	Subscribe(userID string) (<-chan Event, func())
+}
+
+// NewEvent は ID と作成日時を埋めたイベントを作る
+func NewEvent(eventType, userID string, data map[string]any) Event {
+	return Event{
+		ID:        newEventID(),
+		Type:      eventType,
+		UserID:    userID,
+		Data:      data,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Publish は b にイベントを配信する
+// 配信失敗は本処理を止めないためログだけ残す
+func Publish(ctx context.Context, b Broker, ev Event) {
+	if err := b.Publish(ctx, ev); err != nil {
+		log.Printf("realtime: failed to publish %s: %v", ev.Type, err)
+	}
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer は購読者ごとのバッファ数
+// 溢れた場合は遅いクライアントを待たずにイベントを捨てる
+const subscriberBuffer = 16
+
+// Hub はプロセス内で完結する Broker 実装
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan Event]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]map[chan Event]struct{})}
+}
+
+func (h *Hub) Publish(_ context.Context, ev Event) error {
+	h.deliver(ev)
+	return nil
+}
+
+func (h *Hub) Subscribe(userID string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan Event]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs[userID], ch)
+			if len(h.subs[userID]) == 0 {
+				delete(h.subs, userID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// deliver はローカルの購読者にイベントを渡す（ブロックしない）
+func (h *Hub) deliver(ev Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[ev.UserID] {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHubDeliversOnlyToTargetUser(t *testing.T) {
+	hub := NewHub()
+
+	alice, unsubAlice := hub.Subscribe("alice")
+	defer unsubAlice()
+	bob, unsubBob := hub.Subscribe("bob")
+	defer unsubBob()
+
+	ev := NewEvent(EventMatchCreated, "alice", map[string]any{"match_id": "m1"})
+	if err := hub.Publish(context.Background(), ev); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	select {
+	case got := <-alice:
+		if got.ID != ev.ID || got.Type != EventMatchCreated {
+			t.Fatalf("unexpected event: %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("alice did not receive event")
+	}
+
+	select {
+	case got := <-bob:
+		t.Fatalf("bob should not receive event, got %+v", got)
+	default:
+	}
+}
+
+func TestHubUnsubscribeClosesChannel(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe("alice")
+	unsubscribe()
+	unsubscribe() // 二重呼び出しでも panic しない
+
+	if _, ok := <-events; ok {
+		t.Fatal("expected closed channel")
+	}
+
+	// 購読者がいなくても Publish は失敗しない
+	if err := hub.Publish(context.Background(), NewEvent(EventReviewReceived, "alice", nil)); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+}
+
+func TestHubDropsWhenSubscriberIsSlow(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe("alice")
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		_ = hub.Publish(context.Background(), NewEvent(EventReviewReceived, "alice", nil))
+	}
+
+	if len(events) != subscriberBuffer {
+		t.Fatalf("expected %d buffered events, got %d", subscriberBuffer, len(events))
+	}
+}
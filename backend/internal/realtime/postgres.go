@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotifyChannel は LISTEN/NOTIFY で使うチャネル名
+const NotifyChannel = "kiratto_events"
+
+// maxNotifyPayload は NOTIFY のペイロード上限（Postgres の既定 8000 バイト未満）
+const maxNotifyPayload = 7999
+
+// PGBroker は Postgres の LISTEN/NOTIFY 経由でイベントを配る Broker 実装
+// 複数インスタンス構成でも、どのインスタンスに接続しているクライアントにも届く
+// Publish したイベントは自インスタンスにも LISTEN 経由で戻ってくる
+type PGBroker struct {
+	pool *pgxpool.Pool
+	hub  *Hub
+}
+
+func NewPGBroker(pool *pgxpool.Pool) *PGBroker {
+	return &PGBroker{pool: pool, hub: NewHub()}
+}
+
+func (b *PGBroker) Publish(ctx context.Context, ev Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("event payload too large: %d bytes", len(payload))
+	}
+
+	_, err = b.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, NotifyChannel, string(payload))
+	return err
+}
+
+func (b *PGBroker) Subscribe(userID string) (<-chan Event, func()) {
+	return b.hub.Subscribe(userID)
+}
+
+// Listen は ctx がキャンセルされるまで NOTIFY を受信してローカルの購読者へ配る
+// 接続が切れた場合は少し待って再接続する
+func (b *PGBroker) Listen(ctx context.Context) {
+	for {
+		if err := b.listenOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("realtime: listen failed, retrying: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (b *PGBroker) listenOnce(ctx context.Context) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NotifyChannel); err != nil {
+		return err
+	}
+	// プールに戻す前に LISTEN を解除しておく
+	defer conn.Exec(context.Background(), "UNLISTEN *")
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var ev Event
+		if err := json.Unmarshal([]byte(n.Payload), &ev); err != nil {
+			log.Printf("realtime: invalid notification payload: %v", err)
+			continue
+		}
+		b.hub.deliver(ev)
+	}
+}
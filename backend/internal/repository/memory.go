@@ -0,0 +1,871 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+// NewMemory はメモリ上で動くリポジトリ一式を返す（テスト・オフライン用）
+// 一意制約・参照先の確認・レビューのロック解除・停止や非表示の扱い・退会時の削除と匿名化は
+// Postgres 版と同じ規則で扱う
+func NewMemory() Repositories {
+	return NewMemoryAt(time.Now)
+}
+
+// NewMemoryAt は作成日時などに now を使う NewMemory（created_at が同じ行のテスト用）
+func NewMemoryAt(now func() time.Time) Repositories {
+	m := &memory{
+		now:           now,
+		users:         make(map[string]*memoryRow[memoryUser]),
+		works:         make(map[string]*memoryRow[Work]),
+		swipes:        make(map[swipeKey]*memoryRow[swipe]),
+		matches:       make(map[string]*memoryRow[Match]),
+		reviews:       make(map[string]*memoryRow[memoryReview]),
+		handleHistory: make(map[string]handleHold),
+		blocks:        make(map[blockKey]*memoryRow[block]),
+		devices:       make(map[string]*memoryRow[device]),
+		notifications: make(map[string]*memoryRow[memoryNotification]),
+		preferences:   make(map[preferenceKey]bool),
+		messages:      make(map[string]*memoryRow[Message]),
+		reports:       make(map[string]*memoryRow[Report]),
+	}
+	return Repositories{
+		Users:         memoryUsers{m},
+		Works:         memoryWorks{m},
+		Swipes:        memorySwipes{m},
+		Matches:       memoryMatches{m},
+		Reviews:       memoryReviews{m},
+		Accounts:      memoryAccounts{m},
+		Handles:       memoryHandles{m},
+		Blocks:        memoryBlocks{m},
+		Devices:       memoryDevices{m},
+		Notifications: memoryNotifications{m},
+		Messages:      memoryMessages{m},
+		Reports:       memoryReports{m},
+		Stats:         memoryStats{m},
+	}
+}
+
+// memory はすべてのテーブルを 1 つのロックで守る（テーブルをまたぐ条件を扱うため）
+type memory struct {
+	mu  sync.Mutex
+	seq int64
+	now func() time.Time
+
+	users         map[string]*memoryRow[memoryUser]
+	works         map[string]*memoryRow[Work]
+	swipes        map[swipeKey]*memoryRow[swipe]
+	matches       map[string]*memoryRow[Match]
+	reviews       map[string]*memoryRow[memoryReview]
+	handleHistory map[string]handleHold
+	blocks        map[blockKey]*memoryRow[block]
+	devices       map[string]*memoryRow[device]
+	notifications map[string]*memoryRow[memoryNotification]
+	preferences   map[preferenceKey]bool
+	messages      map[string]*memoryRow[Message]
+	reports       map[string]*memoryRow[Report]
+}
+
+// memoryRow は作成順を持った行
+// 同じ時刻に作られた行も新しい順に並べられるよう、時刻ではなく seq で並べる
+type memoryRow[T any] struct {
+	v   T
+	seq int64
+}
+
+func (m *memory) next() int64 {
+	m.seq++
+	return m.seq
+}
+
+// newest は行を新しい順に並べて返す
+func newest[K comparable, T any](rows map[K]*memoryRow[T]) []*memoryRow[T] {
+	list := oldest(rows)
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list
+}
+
+// oldest は行を古い順に並べて返す
+func oldest[K comparable, T any](rows map[K]*memoryRow[T]) []*memoryRow[T] {
+	list := make([]*memoryRow[T], 0, len(rows))
+	for _, r := range rows {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].seq < list[j].seq })
+	return list
+}
+
+// newID は gen_random_uuid() と同じ形（UUID v4）の ID を作る
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// activeUser は停止中・退会申請中でないユーザー
+func (m *memory) activeUser(id string) (User, bool) {
+	u, ok := m.users[id]
+	if !ok || u.v.SuspendedAt != nil || u.v.DeletedAt != nil {
+		return User{}, false
+	}
+	return u.v.User, true
+}
+
+// memoryUser は users の行と、ハンドルを最後に変えた日時
+type memoryUser struct {
+	User
+	handleChangedAt *time.Time
+}
+
+type memoryUsers struct{ m *memory }
+
+func (r memoryUsers) Create(ctx context.Context, u User) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u.Email = strings.ToLower(u.Email)
+	for _, existing := range r.m.users {
+		if existing.v.Email == u.Email {
+			return "", ErrConflict
+		}
+	}
+
+	u.ID = newID()
+	r.m.users[u.ID] = &memoryRow[memoryUser]{v: memoryUser{User: u}, seq: r.m.next()}
+	return u.ID, nil
+}
+
+func (r memoryUsers) EmailExists(ctx context.Context, email string) (bool, error) {
+	_, err := r.FindByEmail(ctx, email)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r memoryUsers) FindByEmail(ctx context.Context, email string) (User, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	email = strings.ToLower(email)
+	for _, u := range r.m.users {
+		if strings.ToLower(u.v.Email) == email {
+			return u.v.User, nil
+		}
+	}
+	return User{}, ErrNotFound
+}
+
+func (r memoryUsers) Get(ctx context.Context, id string) (User, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return u.v.User, nil
+}
+
+func (r memoryUsers) List(ctx context.Context) ([]User, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var users []User
+	for _, u := range newest(r.m.users) {
+		users = append(users, u.v.User)
+	}
+	return users, nil
+}
+
+func (r memoryUsers) UpdateProfile(ctx context.Context, id string, p ProfileUpdate) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if p.Username != nil {
+		u.v.Username = *p.Username
+	}
+	switch {
+	case p.ResetBio:
+		u.v.Bio = nil
+	case p.Bio != nil:
+		bio := *p.Bio
+		u.v.Bio = &bio
+	}
+	return nil
+}
+
+func (r memoryUsers) SetIconPath(ctx context.Context, id string, iconPath *string) (*string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	old := u.v.IconPath
+	u.v.IconPath = copyString(iconPath)
+	return old, nil
+}
+
+func (r memoryUsers) SetAdmin(ctx context.Context, id string, isAdmin bool) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return ErrNotFound
+	}
+	u.v.IsAdmin = isAdmin
+	return nil
+}
+
+func (r memoryUsers) Suspend(ctx context.Context, id string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if u.v.SuspendedAt == nil {
+		now := r.m.now()
+		u.v.SuspendedAt = &now
+	}
+	return nil
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+type memoryWorks struct{ m *memory }
+
+func (r memoryWorks) Save(ctx context.Context, w Work) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.users[w.UserID]; !ok {
+		return "", ErrMissingReference
+	}
+
+	// 同じユーザーの同じ画像はタイトルと説明だけ上書きする
+	for _, existing := range r.m.works {
+		if existing.v.UserID == w.UserID && samePath(existing.v.ImagePath, w.ImagePath) {
+			existing.v.Title = w.Title
+			existing.v.Description = w.Description
+			return existing.v.ID, nil
+		}
+	}
+
+	w.ID = newID()
+	w.CreatedAt = r.m.now()
+	r.m.works[w.ID] = &memoryRow[Work]{v: w, seq: r.m.next()}
+	return w.ID, nil
+}
+
+func samePath(a, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func (r memoryWorks) ListByUser(ctx context.Context, userID string) ([]Work, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var works []Work
+	for _, w := range newest(r.m.works) {
+		if w.v.UserID == userID {
+			works = append(works, w.v)
+		}
+	}
+	return works, nil
+}
+
+func (r memoryWorks) UnswipedIDs(ctx context.Context, userID string) ([]string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var ids []string
+	for _, w := range r.m.works {
+		if w.v.UserID == userID || w.v.HiddenAt != nil {
+			continue
+		}
+		if _, swiped := r.m.swipes[swipeKey{userID, w.v.ID}]; swiped {
+			continue
+		}
+		if _, ok := r.m.activeUser(w.v.UserID); !ok {
+			continue
+		}
+		ids = append(ids, w.v.ID)
+	}
+	return ids, nil
+}
+
+func (r memoryWorks) Feed(ctx context.Context, ids []string) ([]FeedWork, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var works []FeedWork
+	for _, id := range ids {
+		w, ok := r.m.works[id]
+		if !ok {
+			continue
+		}
+		author, ok := r.m.users[w.v.UserID]
+		if !ok {
+			continue
+		}
+		works = append(works, FeedWork{Work: w.v, Username: author.v.Username, IconPath: author.v.IconPath})
+	}
+	return works, nil
+}
+
+func (r memoryWorks) Owner(ctx context.Context, workID string) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	w, ok := r.m.works[workID]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return w.v.UserID, nil
+}
+
+func (r memoryWorks) ListAll(ctx context.Context) ([]FeedWork, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var works []FeedWork
+	for _, w := range newest(r.m.works) {
+		author, ok := r.m.users[w.v.UserID]
+		if !ok {
+			continue
+		}
+		works = append(works, FeedWork{Work: w.v, Username: author.v.Username, IconPath: author.v.IconPath})
+	}
+	return works, nil
+}
+
+func (r memoryWorks) CountVisible(ctx context.Context, userID string) (int, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	n := 0
+	for _, w := range r.m.works {
+		if w.v.UserID == userID && w.v.HiddenAt == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r memoryWorks) PageVisible(ctx context.Context, userID string, after *Cursor, limit int) ([]Work, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var works []Work
+	for _, w := range r.m.works {
+		if w.v.UserID == userID && w.v.HiddenAt == nil && after.before(w.v.CreatedAt, w.v.ID) {
+			works = append(works, w.v)
+		}
+	}
+	sortByCursor(works, false, func(w Work) (time.Time, string) { return w.CreatedAt, w.ID })
+	return works[:min(limit, len(works))], nil
+}
+
+// before は (createdAt, id) が c より前か（c が nil なら常に true）
+func (c *Cursor) before(createdAt time.Time, id string) bool {
+	if c == nil {
+		return true
+	}
+	return createdAt.Before(c.CreatedAt) || (createdAt.Equal(c.CreatedAt) && id < c.ID)
+}
+
+// after は (createdAt, id) が c より後か（c が nil なら常に true）
+func (c *Cursor) after(createdAt time.Time, id string) bool {
+	if c == nil {
+		return true
+	}
+	return createdAt.After(c.CreatedAt) || (createdAt.Equal(c.CreatedAt) && id > c.ID)
+}
+
+// sortByCursor は (created_at, id) の順に並べる（ascending でなければ新しい順）
+func sortByCursor[T any](list []T, ascending bool, key func(T) (time.Time, string)) {
+	sort.Slice(list, func(i, j int) bool {
+		ti, idi := key(list[i])
+		tj, idj := key(list[j])
+		less := ti.Before(tj) || (ti.Equal(tj) && idi < idj)
+		if ascending {
+			return less
+		}
+		return tj.Before(ti) || (tj.Equal(ti) && idj < idi)
+	})
+}
+
+// swipeKey は swipes の一意制約 (from_user_id, to_work_id)
+type swipeKey struct {
+	fromUserID string
+	toWorkID   string
+}
+
+type swipe struct {
+	id           string
+	toWorkUserID string
+	isLike       bool
+	createdAt    time.Time
+}
+
+type memorySwipes struct{ m *memory }
+
+func (r memorySwipes) Save(ctx context.Context, fromUserID, toWorkID string, isLike bool) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	w, ok := r.m.works[toWorkID]
+	if !ok || w.v.UserID == fromUserID {
+		return ErrNotFound
+	}
+	if _, ok := r.m.users[fromUserID]; !ok {
+		return ErrMissingReference
+	}
+
+	key := swipeKey{fromUserID, toWorkID}
+	if existing, ok := r.m.swipes[key]; ok {
+		existing.v.isLike = isLike
+		existing.v.createdAt = r.m.now()
+		return nil
+	}
+	r.m.swipes[key] = &memoryRow[swipe]{
+		v:   swipe{id: newID(), toWorkUserID: w.v.UserID, isLike: isLike, createdAt: r.m.now()},
+		seq: r.m.next(),
+	}
+	return nil
+}
+
+func (r memorySwipes) FindLike(ctx context.Context, fromUserID, toUserID string) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	// 複数あれば最初にいいねした作品
+	var found *memoryRow[swipe]
+	var workID string
+	for key, s := range r.m.swipes {
+		if key.fromUserID != fromUserID || s.v.toWorkUserID != toUserID || !s.v.isLike {
+			continue
+		}
+		if found == nil || s.seq < found.seq {
+			found, workID = s, key.toWorkID
+		}
+	}
+	if found == nil {
+		return "", ErrNotFound
+	}
+	return workID, nil
+}
+
+type memoryMatches struct{ m *memory }
+
+func (r memoryMatches) Create(ctx context.Context, match Match) (Match, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	match = match.ordered()
+	for _, id := range []string{match.User1ID, match.User2ID} {
+		if _, ok := r.m.users[id]; !ok {
+			return Match{}, ErrMissingReference
+		}
+	}
+	for _, id := range []string{match.Work1ID, match.Work2ID} {
+		if _, ok := r.m.works[id]; !ok {
+			return Match{}, ErrMissingReference
+		}
+	}
+	for _, existing := range r.m.matches {
+		if existing.v.User1ID == match.User1ID && existing.v.User2ID == match.User2ID {
+			return Match{}, ErrConflict
+		}
+	}
+
+	match.ID = newID()
+	match.CreatedAt = r.m.now()
+	r.m.matches[match.ID] = &memoryRow[Match]{v: match, seq: r.m.next()}
+	return match, nil
+}
+
+func (r memoryMatches) Get(ctx context.Context, id string) (Match, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	match, ok := r.m.matches[id]
+	if !ok {
+		return Match{}, ErrNotFound
+	}
+	return match.v, nil
+}
+
+func (r memoryMatches) ListForUser(ctx context.Context, userID string) ([]MatchSummary, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var matches []MatchSummary
+	for _, row := range newest(r.m.matches) {
+		partnerID, partnerWorkID, ok := row.v.Partner(userID)
+		if !ok {
+			continue
+		}
+		partner, ok := r.m.activeUser(partnerID)
+		if !ok {
+			continue
+		}
+		work, ok := r.m.works[partnerWorkID]
+		if !ok || work.v.HiddenAt != nil {
+			continue
+		}
+		_, reviewed := r.m.findReview(row.v.ID, userID)
+
+		matches = append(matches, MatchSummary{
+			MatchID:       row.v.ID,
+			UserID:        partner.ID,
+			Username:      partner.Username,
+			IconPath:      partner.IconPath,
+			WorkImagePath: work.v.ImagePath,
+			WorkTitle:     work.v.Title,
+			IsReviewed:    reviewed,
+		})
+	}
+	return matches, nil
+}
+
+type memoryReviews struct{ m *memory }
+
+// memoryReview は reviews の行と、既読・編集・非表示・匿名化の状態、編集履歴、リアクション
+// 匿名化したレビューは MatchID と FromUserID が空
+type memoryReview struct {
+	Review
+	readAt       *time.Time
+	editedAt     *time.Time
+	hiddenAt     *time.Time
+	anonymizedAt *time.Time
+	revisions    []ReviewRevision
+	reactions    []string
+}
+
+// findReview は matchID で fromUserID が送ったレビュー
+func (m *memory) findReview(matchID, fromUserID string) (Review, bool) {
+	for _, rv := range m.reviews {
+		if rv.v.MatchID == matchID && rv.v.FromUserID == fromUserID {
+			return rv.v.Review, true
+		}
+	}
+	return Review{}, false
+}
+
+// unlocked は ReviewUnlockedCondition と同じ条件
+// 同じマッチで受信者も送信者へレビューを送っていれば読める（匿名化したレビューは常に読める）
+func (m *memory) unlocked(rv Review) bool {
+	if row, ok := m.reviews[rv.ID]; ok && row.v.anonymizedAt != nil {
+		return true
+	}
+	for _, my := range m.reviews {
+		if my.v.MatchID == rv.MatchID && my.v.FromUserID == rv.ToUserID && my.v.ToUserID == rv.FromUserID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r memoryReviews) Create(ctx context.Context, rv Review) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.matches[rv.MatchID]; !ok {
+		return "", ErrMissingReference
+	}
+	for _, id := range []string{rv.FromUserID, rv.ToUserID} {
+		if _, ok := r.m.users[id]; !ok {
+			return "", ErrMissingReference
+		}
+	}
+	if _, ok := r.m.works[rv.WorkID]; !ok {
+		return "", ErrMissingReference
+	}
+	if _, exists := r.m.findReview(rv.MatchID, rv.FromUserID); exists {
+		return "", ErrConflict
+	}
+
+	rv.ID = newID()
+	rv.CreatedAt = r.m.now()
+	rv.Sections = append([]guideline.Section{}, rv.Sections...)
+	rv.QualityFlags = append([]string{}, rv.QualityFlags...)
+	r.m.reviews[rv.ID] = &memoryRow[memoryReview]{v: memoryReview{Review: rv}, seq: r.m.next()}
+	return rv.ID, nil
+}
+
+func (r memoryReviews) Get(ctx context.Context, id string) (Review, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	rv, ok := r.m.reviews[id]
+	if !ok {
+		return Review{}, ErrNotFound
+	}
+	review := rv.v.Review
+	review.Unlocked = r.m.unlocked(review)
+	return review, nil
+}
+
+func (r memoryReviews) FindID(ctx context.Context, matchID, fromUserID string) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	rv, ok := r.m.findReview(matchID, fromUserID)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return rv.ID, nil
+}
+
+func (r memoryReviews) RecentComments(ctx context.Context, userID, excludeReviewID string, limit int) ([]string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	comments := []string{}
+	for _, rv := range newest(r.m.reviews) {
+		if len(comments) == limit {
+			break
+		}
+		if rv.v.FromUserID == userID && rv.v.ID != excludeReviewID {
+			comments = append(comments, rv.v.Comment)
+		}
+	}
+	return comments, nil
+}
+
+func (r memoryReviews) Received(ctx context.Context, userID string, includeLocked bool) ([]ReceivedReview, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var reviews []ReceivedReview
+	for _, row := range newest(r.m.reviews) {
+		rv := row.v.Review
+		if rv.ToUserID != userID || row.v.hiddenAt != nil {
+			continue
+		}
+		unlocked := r.m.unlocked(rv)
+		if !includeLocked && !unlocked {
+			continue
+		}
+		// 匿名化したレビューは投稿者なしで返す
+		var from User
+		if rv.FromUserID != "" {
+			var ok bool
+			if from, ok = r.m.activeUser(rv.FromUserID); !ok {
+				continue
+			}
+		}
+		// 作品が削除されていれば作品なしで返す
+		var work Work
+		if w, ok := r.m.works[rv.WorkID]; ok {
+			work = w.v
+		}
+
+		received := ReceivedReview{
+			ID:            rv.ID,
+			MatchID:       rv.MatchID,
+			FromUserID:    from.ID,
+			FromUsername:  from.Username,
+			FromIconPath:  from.IconPath,
+			WorkID:        work.ID,
+			WorkImagePath: work.ImagePath,
+			WorkTitle:     work.Title,
+			Sections:      []guideline.Section{},
+			Locked:        !unlocked,
+			Edited:        row.v.editedAt != nil,
+			Anonymous:     row.v.anonymizedAt != nil,
+			CreatedAt:     rv.CreatedAt,
+		}
+		// ロック中は本文を返さない
+		if unlocked {
+			received.Comment = rv.Comment
+			received.Sections = append(received.Sections, rv.Sections...)
+		}
+		reviews = append(reviews, received)
+	}
+	return reviews, nil
+}
+
+func (r memoryReviews) Statuses(ctx context.Context, userID string) ([]ReviewStatus, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var statuses []ReviewStatus
+	for _, row := range newest(r.m.matches) {
+		partnerID, _, ok := row.v.Partner(userID)
+		if !ok {
+			continue
+		}
+		partner, ok := r.m.users[partnerID]
+		if !ok {
+			continue
+		}
+
+		status := ReviewStatus{
+			MatchID:  row.v.ID,
+			UserID:   partner.v.ID,
+			Username: partner.v.Username,
+			IconPath: partner.v.IconPath,
+		}
+		_, status.HasSentReview = r.m.findReview(row.v.ID, userID)
+		if received, ok := r.m.findReview(row.v.ID, partnerID); ok && r.m.reviews[received.ID].v.hiddenAt == nil {
+			status.ReceivedReviewID = &received.ID
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (r memoryReviews) Edit(ctx context.Context, id string, check func(Review) error, e ReviewEdit) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	row, ok := r.m.reviews[id]
+	if !ok {
+		return ErrNotFound
+	}
+	old := row.v.Review
+	old.Unlocked = r.m.unlocked(old)
+	if err := check(old); err != nil {
+		return err
+	}
+
+	now := r.m.now()
+	row.v.revisions = append(row.v.revisions, ReviewRevision{Comment: old.Comment, Sections: old.Sections, CreatedAt: now})
+	row.v.Comment = e.Comment
+	row.v.Sections = append([]guideline.Section{}, e.Sections...)
+	row.v.QualityFlags = append([]string{}, e.QualityFlags...)
+	row.v.editedAt = &now
+	return nil
+}
+
+func (r memoryReviews) Revisions(ctx context.Context, id string) ([]ReviewRevision, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	row, ok := r.m.reviews[id]
+	if !ok {
+		return nil, nil
+	}
+	return append([]ReviewRevision(nil), row.v.revisions...), nil
+}
+
+func (r memoryReviews) MarkRead(ctx context.Context, id string) (bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	return r.m.markReviewRead(id), nil
+}
+
+// markReviewRead は最初に読んだ日時だけを残す
+func (m *memory) markReviewRead(id string) bool {
+	row, ok := m.reviews[id]
+	if !ok || row.v.readAt != nil {
+		return false
+	}
+	now := m.now()
+	row.v.readAt = &now
+	return true
+}
+
+func (r memoryReviews) React(ctx context.Context, id, reaction string) (bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	row, ok := r.m.reviews[id]
+	if !ok {
+		return false, ErrMissingReference
+	}
+	r.m.markReviewRead(id)
+	for _, existing := range row.v.reactions {
+		if existing == reaction {
+			return false, nil
+		}
+	}
+	row.v.reactions = append(row.v.reactions, reaction)
+	return true, nil
+}
+
+func (r memoryReviews) Reactions(ctx context.Context, id string) ([]string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	reactions := []string{}
+	if row, ok := r.m.reviews[id]; ok {
+		reactions = append(reactions, row.v.reactions...)
+	}
+	return reactions, nil
+}
+
+func (r memoryReviews) Sent(ctx context.Context, userID string, after *Cursor, ascending bool, limit int) ([]SentReview, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var reviews []SentReview
+	for _, row := range r.m.reviews {
+		rv := row.v
+		if rv.FromUserID != userID {
+			continue
+		}
+		if ascending && !after.after(rv.CreatedAt, rv.ID) || !ascending && !after.before(rv.CreatedAt, rv.ID) {
+			continue
+		}
+		to, ok := r.m.users[rv.ToUserID]
+		if !ok {
+			continue
+		}
+		var work Work
+		if w, ok := r.m.works[rv.WorkID]; ok {
+			work = w.v
+		}
+
+		reviews = append(reviews, SentReview{
+			ID:            rv.ID,
+			MatchID:       rv.MatchID,
+			ToUserID:      to.v.ID,
+			ToUsername:    to.v.Username,
+			ToIconPath:    to.v.IconPath,
+			WorkID:        work.ID,
+			WorkTitle:     work.Title,
+			WorkImagePath: work.ImagePath,
+			Comment:       rv.Comment,
+			Sections:      append([]guideline.Section{}, rv.Sections...),
+			ReviewedBack:  r.m.unlocked(rv.Review),
+			Reactions:     append([]string{}, rv.reactions...),
+			ReadAt:        rv.readAt,
+			EditedAt:      rv.editedAt,
+			CreatedAt:     rv.CreatedAt,
+		})
+	}
+	sortByCursor(reviews, ascending, func(rv SentReview) (time.Time, string) { return rv.CreatedAt, rv.ID })
+	return reviews[:min(limit, len(reviews))], nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+)
+
+type memoryAccounts struct{ m *memory }
+
+func (r memoryAccounts) ScheduleDeletion(ctx context.Context, userID string) (time.Time, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[userID]
+	if !ok {
+		return time.Time{}, ErrNotFound
+	}
+	if u.v.DeletedAt == nil {
+		now := r.m.now()
+		u.v.DeletedAt = &now
+	}
+	return *u.v.DeletedAt, nil
+}
+
+func (r memoryAccounts) CancelDeletion(ctx context.Context, userID string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[userID]
+	if !ok || u.v.DeletedAt == nil {
+		return ErrNotFound
+	}
+	u.v.DeletedAt = nil
+	return nil
+}
+
+func (r memoryAccounts) DueForPurge(ctx context.Context, before time.Time) ([]string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var ids []string
+	for _, u := range oldest(r.m.users) {
+		if u.v.DeletedAt != nil && !u.v.DeletedAt.After(before) {
+			ids = append(ids, u.v.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r memoryAccounts) Purge(ctx context.Context, userID string) ([]string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[userID]
+	if !ok || u.v.DeletedAt == nil {
+		// 取り消された・既に削除された
+		return nil, ErrNotFound
+	}
+
+	var objects []string
+	for _, w := range r.m.works {
+		if w.v.UserID == userID && w.v.ImagePath != nil {
+			objects = append(objects, *w.v.ImagePath)
+		}
+	}
+	if u.v.IconPath != nil {
+		objects = append(objects, *u.v.IconPath)
+	}
+
+	r.m.deleteUser(userID)
+	return objects, nil
+}
+
+// deleteUser はユーザーを消し、Postgres の外部キーと同じように関連する行を消す・null にする
+// 他の人に書いたレビューは匿名化して残し、編集履歴は消す
+func (m *memory) deleteUser(userID string) {
+	now := m.now()
+
+	deletedWorks := map[string]bool{}
+	for id, w := range m.works {
+		if w.v.UserID == userID {
+			deletedWorks[id] = true
+			delete(m.works, id)
+		}
+	}
+
+	for key, s := range m.swipes {
+		if key.fromUserID == userID || s.v.toWorkUserID == userID || deletedWorks[key.toWorkID] {
+			delete(m.swipes, key)
+		}
+	}
+
+	deletedMatches := map[string]bool{}
+	for id, match := range m.matches {
+		if match.v.User1ID == userID || match.v.User2ID == userID ||
+			deletedWorks[match.v.Work1ID] || deletedWorks[match.v.Work2ID] {
+			deletedMatches[id] = true
+			delete(m.matches, id)
+		}
+	}
+
+	deletedReviews := map[string]bool{}
+	for id, rv := range m.reviews {
+		switch {
+		case rv.v.ToUserID == userID:
+			deletedReviews[id] = true
+			delete(m.reviews, id)
+			continue
+		case rv.v.FromUserID == userID:
+			rv.v.FromUserID = ""
+			rv.v.revisions = nil
+			rv.v.anonymizedAt = &now
+		}
+		if deletedMatches[rv.v.MatchID] {
+			rv.v.MatchID = ""
+		}
+		if deletedWorks[rv.v.WorkID] {
+			rv.v.WorkID = ""
+		}
+	}
+
+	for id, msg := range m.messages {
+		if msg.v.SenderID == userID || deletedMatches[msg.v.MatchID] {
+			delete(m.messages, id)
+		}
+	}
+
+	for id, n := range m.notifications {
+		if n.v.UserID == userID || deletedMatches[n.v.MatchID] || deletedReviews[n.v.ReviewID] {
+			delete(m.notifications, id)
+			continue
+		}
+		if n.v.ActorUserID == userID {
+			n.v.ActorUserID = ""
+		}
+	}
+	for key := range m.preferences {
+		if key.userID == userID {
+			delete(m.preferences, key)
+		}
+	}
+
+	for token, d := range m.devices {
+		if d.v.userID == userID {
+			delete(m.devices, token)
+		}
+	}
+	for key := range m.blocks {
+		if key.blockerID == userID || key.blockedID == userID {
+			delete(m.blocks, key)
+		}
+	}
+	for handle, hold := range m.handleHistory {
+		if hold.userID == userID {
+			delete(m.handleHistory, handle)
+		}
+	}
+
+	for _, rep := range m.reports {
+		if rep.v.ReporterID != nil && *rep.v.ReporterID == userID {
+			rep.v.ReporterID = nil
+		}
+		if rep.v.HandledBy != nil && *rep.v.HandledBy == userID {
+			rep.v.HandledBy = nil
+		}
+	}
+
+	delete(m.users, userID)
+}
+
+func (r memoryAccounts) Export(ctx context.Context, userID string) (Export, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[userID]
+	if !ok {
+		return Export{}, ErrNotFound
+	}
+
+	e := Export{
+		Profile: ExportProfile{
+			ID:        u.v.ID,
+			Username:  u.v.Username,
+			Handle:    copyString(u.v.Handle),
+			Email:     u.v.Email,
+			Bio:       copyString(u.v.Bio),
+			IconPath:  copyString(u.v.IconPath),
+			DeletedAt: u.v.DeletedAt,
+		},
+		Works:           []ExportWork{},
+		Swipes:          []ExportSwipe{},
+		Matches:         []ExportMatch{},
+		ReviewsSent:     []ExportSentReview{},
+		ReviewsReceived: []ExportReceivedReview{},
+	}
+
+	for _, w := range oldest(r.m.works) {
+		if w.v.UserID == userID {
+			e.Works = append(e.Works, ExportWork{
+				ID:          w.v.ID,
+				Title:       w.v.Title,
+				Description: w.v.Description,
+				ImagePath:   w.v.ImagePath,
+				CreatedAt:   w.v.CreatedAt,
+			})
+		}
+	}
+
+	for key, s := range r.m.swipes {
+		if key.fromUserID == userID {
+			e.Swipes = append(e.Swipes, ExportSwipe{
+				ID:        s.v.id,
+				ToWorkID:  key.toWorkID,
+				IsLike:    s.v.isLike,
+				CreatedAt: s.v.createdAt,
+			})
+		}
+	}
+	// やり直したスワイプは created_at が新しくなるので、作成順ではなく created_at で並べる
+	sortByCursor(e.Swipes, true, func(s ExportSwipe) (time.Time, string) { return s.CreatedAt, s.ID })
+
+	for _, match := range oldest(r.m.matches) {
+		partnerID, partnerWorkID, ok := match.v.Partner(userID)
+		if !ok {
+			continue
+		}
+		_, myWorkID, _ := match.v.Partner(partnerID)
+		e.Matches = append(e.Matches, ExportMatch{
+			ID:            match.v.ID,
+			PartnerID:     partnerID,
+			MyWorkID:      myWorkID,
+			PartnerWorkID: partnerWorkID,
+			CreatedAt:     match.v.CreatedAt,
+		})
+	}
+
+	for _, row := range oldest(r.m.reviews) {
+		rv := row.v
+		switch {
+		case rv.FromUserID == userID:
+			e.ReviewsSent = append(e.ReviewsSent, ExportSentReview{
+				ID:        rv.ID,
+				MatchID:   nullString(rv.MatchID),
+				ToUserID:  rv.ToUserID,
+				WorkID:    nullString(rv.WorkID),
+				Comment:   rv.Comment,
+				Sections:  append([]guideline.Section{}, rv.Sections...),
+				EditedAt:  rv.editedAt,
+				ReadAt:    rv.readAt,
+				CreatedAt: rv.CreatedAt,
+			})
+		// 受け取ったレビューは Give-to-Get を崩さないよう、読めるものだけを含める
+		case rv.ToUserID == userID && r.m.unlocked(rv.Review):
+			e.ReviewsReceived = append(e.ReviewsReceived, ExportReceivedReview{
+				ID:          rv.ID,
+				MatchID:     nullString(rv.MatchID),
+				FromUserID:  nullString(rv.FromUserID),
+				WorkID:      nullString(rv.WorkID),
+				Comment:     rv.Comment,
+				Sections:    append([]guideline.Section{}, rv.Sections...),
+				CreatedAt:   rv.CreatedAt,
+				MyReactions: append([]string{}, rv.reactions...),
+			})
+		}
+	}
+
+	return e, nil
+}
+
+// nullString は空文字を nil にする（null になりうる列の代わり）
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// blockKey は user_blocks の主キー (blocker_id, blocked_id)
+type blockKey struct {
+	blockerID string
+	blockedID string
+}
+
+// block は user_blocks の行
+type block struct {
+	blockKey
+	createdAt time.Time
+}
+
+type memoryBlocks struct{ m *memory }
+
+func (r memoryBlocks) Block(ctx context.Context, blockerID, blockedID string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	for _, id := range []string{blockerID, blockedID} {
+		if _, ok := r.m.users[id]; !ok {
+			return ErrMissingReference
+		}
+	}
+
+	key := blockKey{blockerID, blockedID}
+	if _, ok := r.m.blocks[key]; !ok {
+		r.m.blocks[key] = &memoryRow[block]{v: block{blockKey: key, createdAt: r.m.now()}, seq: r.m.next()}
+	}
+	return nil
+}
+
+func (r memoryBlocks) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	delete(r.m.blocks, blockKey{blockerID, blockedID})
+	return nil
+}
+
+func (r memoryBlocks) List(ctx context.Context, blockerID string) ([]BlockedUser, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var users []BlockedUser
+	for _, b := range newest(r.m.blocks) {
+		if b.v.blockerID != blockerID {
+			continue
+		}
+		u, ok := r.m.users[b.v.blockedID]
+		if !ok {
+			continue
+		}
+		users = append(users, BlockedUser{
+			UserID:    u.v.ID,
+			Username:  u.v.Username,
+			IconPath:  u.v.IconPath,
+			CreatedAt: b.v.createdAt,
+		})
+	}
+	return users, nil
+}
+
+func (r memoryBlocks) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	_, ab := r.m.blocks[blockKey{userA, userB}]
+	_, ba := r.m.blocks[blockKey{userB, userA}]
+	return ab || ba, nil
+}
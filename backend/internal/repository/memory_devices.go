@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+)
+
+// device は device_tokens の行（トークンをキーにする）
+type device struct {
+	userID   string
+	platform string
+}
+
+type memoryDevices struct{ m *memory }
+
+func (r memoryDevices) Register(ctx context.Context, userID string, d notify.Device) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.users[userID]; !ok {
+		return ErrMissingReference
+	}
+
+	// 同じトークンが別のユーザーにあれば付け替える
+	if existing, ok := r.m.devices[d.Token]; ok {
+		existing.v = device{userID: userID, platform: d.Platform}
+		return nil
+	}
+	r.m.devices[d.Token] = &memoryRow[device]{v: device{userID: userID, platform: d.Platform}, seq: r.m.next()}
+	return nil
+}
+
+func (r memoryDevices) Unregister(ctx context.Context, userID, token string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if d, ok := r.m.devices[token]; ok && d.v.userID == userID {
+		delete(r.m.devices, token)
+	}
+	return nil
+}
+
+func (r memoryDevices) RemoveForUser(ctx context.Context, userID string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	for token, d := range r.m.devices {
+		if d.v.userID == userID {
+			delete(r.m.devices, token)
+		}
+	}
+	return nil
+}
+
+func (r memoryDevices) DevicesForUser(ctx context.Context, userID string) ([]notify.Device, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var devices []notify.Device
+	for token, d := range r.m.devices {
+		if d.v.userID == userID {
+			devices = append(devices, notify.Device{Token: token, Platform: d.v.platform})
+		}
+	}
+	return devices, nil
+}
+
+func (r memoryDevices) RemoveToken(ctx context.Context, token string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	delete(r.m.devices, token)
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// handleHold は handle_history の行（以前のハンドルと、手放した人・日時）
+type handleHold struct {
+	userID     string
+	releasedAt time.Time
+}
+
+type memoryHandles struct{ m *memory }
+
+func (r memoryHandles) Change(ctx context.Context, userID, handle string, heldSince time.Time, check func(current *string, changedAt *time.Time) error) (bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	u, ok := r.m.users[userID]
+	if !ok {
+		return false, ErrNotFound
+	}
+
+	current := u.v.Handle
+	if current != nil && *current == handle {
+		return false, nil
+	}
+	if err := check(copyString(current), u.v.handleChangedAt); err != nil {
+		return false, err
+	}
+
+	// 他の人の以前のハンドルは一定期間使えない（自分の以前のハンドルには戻せる）
+	if hold, ok := r.m.handleHistory[handle]; ok && hold.releasedAt.After(heldSince) && hold.userID != userID {
+		return false, ErrConflict
+	}
+	for _, other := range r.m.users {
+		if other.v.Handle != nil && *other.v.Handle == handle {
+			return false, ErrConflict
+		}
+	}
+
+	now := r.m.now()
+	delete(r.m.handleHistory, handle)
+	if current != nil {
+		r.m.handleHistory[*current] = handleHold{userID: userID, releasedAt: now}
+		// 初回の設定は変更回数に数えない
+		u.v.handleChangedAt = &now
+	}
+	u.v.Handle = &handle
+	return true, nil
+}
+
+func (r memoryHandles) Find(ctx context.Context, handle string) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	for _, u := range r.m.users {
+		if u.v.Handle == nil || *u.v.Handle != handle {
+			continue
+		}
+		if _, ok := r.m.activeUser(u.v.ID); ok {
+			return u.v.ID, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+func (r memoryHandles) FindPrevious(ctx context.Context, handle string, since time.Time) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	hold, ok := r.m.handleHistory[handle]
+	if !ok || !hold.releasedAt.After(since) {
+		return "", ErrNotFound
+	}
+	u, ok := r.m.activeUser(hold.userID)
+	if !ok || u.Handle == nil {
+		return "", ErrNotFound
+	}
+	return *u.Handle, nil
+}
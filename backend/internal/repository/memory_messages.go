@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type memoryMessages struct{ m *memory }
+
+func (r memoryMessages) List(ctx context.Context, matchID string, after *Cursor, limit int) ([]Message, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var messages []Message
+	for _, row := range r.m.messages {
+		if row.v.MatchID == matchID && after.before(row.v.CreatedAt, row.v.ID) {
+			messages = append(messages, row.v)
+		}
+	}
+	sortByCursor(messages, false, func(m Message) (time.Time, string) { return m.CreatedAt, m.ID })
+	return messages[:min(limit, len(messages))], nil
+}
+
+func (r memoryMessages) Create(ctx context.Context, msg Message) (Message, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.matches[msg.MatchID]; !ok {
+		return Message{}, ErrMissingReference
+	}
+	if _, ok := r.m.users[msg.SenderID]; !ok {
+		return Message{}, ErrMissingReference
+	}
+
+	msg.ID = newID()
+	msg.ReadAt = nil
+	msg.CreatedAt = r.m.now()
+	r.m.messages[msg.ID] = &memoryRow[Message]{v: msg, seq: r.m.next()}
+	return msg, nil
+}
+
+func (r memoryMessages) MarkRead(ctx context.Context, matchID, senderID string) (int64, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	now := r.m.now()
+	var updated int64
+	for _, row := range r.m.messages {
+		if row.v.MatchID == matchID && row.v.SenderID == senderID && row.v.ReadAt == nil {
+			row.v.ReadAt = &now
+			updated++
+		}
+	}
+	return updated, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// memoryNotification は notifications の行（関連しない ID は空）
+type memoryNotification struct {
+	Notification
+	id        string
+	readAt    *time.Time
+	createdAt time.Time
+}
+
+// preferenceKey は notification_preferences の主キー (user_id, type)
+type preferenceKey struct {
+	userID string
+	typ    string
+}
+
+type memoryNotifications struct{ m *memory }
+
+// disabled は userID が typ の通知をオフにしているか
+func (m *memory) disabled(userID, typ string) bool {
+	enabled, ok := m.preferences[preferenceKey{userID, typ}]
+	return ok && !enabled
+}
+
+func (r memoryNotifications) Create(ctx context.Context, n Notification) (bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.users[n.UserID]; !ok {
+		return false, ErrMissingReference
+	}
+	if _, ok := r.m.users[n.ActorUserID]; n.ActorUserID != "" && !ok {
+		return false, ErrMissingReference
+	}
+	if _, ok := r.m.matches[n.MatchID]; n.MatchID != "" && !ok {
+		return false, ErrMissingReference
+	}
+	if _, ok := r.m.reviews[n.ReviewID]; n.ReviewID != "" && !ok {
+		return false, ErrMissingReference
+	}
+
+	if r.m.disabled(n.UserID, n.Type) {
+		return false, nil
+	}
+	r.m.insertNotification(n)
+	return true, nil
+}
+
+// insertNotification は受信箱に通知を追加する
+func (m *memory) insertNotification(n Notification) {
+	id := newID()
+	m.notifications[id] = &memoryRow[memoryNotification]{
+		v:   memoryNotification{Notification: n, id: id, createdAt: m.now()},
+		seq: m.next(),
+	}
+}
+
+func (r memoryNotifications) RemindUnreviewed(ctx context.Context, typ string, from, to time.Time) ([]Notification, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var created []Notification
+	for _, match := range oldest(r.m.matches) {
+		if match.v.CreatedAt.After(to) || !match.v.CreatedAt.After(from) {
+			continue
+		}
+		for _, pair := range [][2]string{{match.v.User1ID, match.v.User2ID}, {match.v.User2ID, match.v.User1ID}} {
+			userID, partnerID := pair[0], pair[1]
+			if _, reviewed := r.m.findReview(match.v.ID, userID); reviewed {
+				continue
+			}
+			if r.m.disabled(userID, typ) || r.m.notified(userID, typ, match.v.ID) {
+				continue
+			}
+
+			n := Notification{UserID: userID, Type: typ, ActorUserID: partnerID, MatchID: match.v.ID}
+			r.m.insertNotification(n)
+			created = append(created, n)
+		}
+	}
+	return created, nil
+}
+
+// notified は同じマッチについて typ の通知を作ったことがあるか（リマインドは 1 回まで）
+func (m *memory) notified(userID, typ, matchID string) bool {
+	for _, n := range m.notifications {
+		if n.v.UserID == userID && n.v.Type == typ && n.v.MatchID == matchID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r memoryNotifications) List(ctx context.Context, userID string, after *Cursor, unreadOnly bool, limit int) ([]InboxNotification, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var list []InboxNotification
+	for _, row := range r.m.notifications {
+		n := row.v
+		if n.UserID != userID || !after.before(n.createdAt, n.id) || unreadOnly && n.readAt != nil {
+			continue
+		}
+
+		item := InboxNotification{
+			ID:          n.id,
+			Type:        n.Type,
+			ActorUserID: nullString(n.ActorUserID),
+			MatchID:     nullString(n.MatchID),
+			ReviewID:    nullString(n.ReviewID),
+			IsRead:      n.readAt != nil,
+			CreatedAt:   n.createdAt,
+		}
+		if actor, ok := r.m.users[n.ActorUserID]; ok {
+			item.ActorUsername = &actor.v.Username
+			item.ActorIconPath = copyString(actor.v.IconPath)
+		}
+		list = append(list, item)
+	}
+	sortByCursor(list, false, func(n InboxNotification) (time.Time, string) { return n.CreatedAt, n.ID })
+	return list[:min(limit, len(list))], nil
+}
+
+func (r memoryNotifications) CountUnread(ctx context.Context, userID string) (int, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	count := 0
+	for _, n := range r.m.notifications {
+		if n.v.UserID == userID && n.v.readAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r memoryNotifications) MarkRead(ctx context.Context, userID string, ids []string, all bool) (int64, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	selected := map[string]bool{}
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	// 他人の通知は user_id 条件で弾く
+	now := r.m.now()
+	var updated int64
+	for _, n := range r.m.notifications {
+		if n.v.UserID != userID || n.v.readAt != nil || !all && !selected[n.v.id] {
+			continue
+		}
+		n.v.readAt = &now
+		updated++
+	}
+	return updated, nil
+}
+
+func (r memoryNotifications) Preferences(ctx context.Context, userID string) (map[string]bool, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	saved := map[string]bool{}
+	for key, enabled := range r.m.preferences {
+		if key.userID == userID {
+			saved[key.typ] = enabled
+		}
+	}
+	return saved, nil
+}
+
+func (r memoryNotifications) SetPreference(ctx context.Context, userID, typ string, enabled bool) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.users[userID]; !ok {
+		return ErrMissingReference
+	}
+	r.m.preferences[preferenceKey{userID, typ}] = enabled
+	return nil
+}
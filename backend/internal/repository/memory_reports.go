@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+)
+
+type memoryReports struct{ m *memory }
+
+// reportOpen は未処理（open / triaged）の通報か
+func reportOpen(rep Report) bool {
+	return rep.Status == "open" || rep.Status == "triaged"
+}
+
+func (r memoryReports) TargetOwner(ctx context.Context, targetType, targetID string) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	return r.m.targetOwner(targetType, targetID)
+}
+
+// targetOwner は通報対象の持ち主を返す
+// 退会などで持ち主がいない（匿名化したレビュー）ときも ErrNotFound
+func (m *memory) targetOwner(targetType, targetID string) (string, error) {
+	var ownerID string
+	switch targetType {
+	case "review":
+		if rv, ok := m.reviews[targetID]; ok {
+			ownerID = rv.v.FromUserID
+		}
+	case "work":
+		if w, ok := m.works[targetID]; ok {
+			ownerID = w.v.UserID
+		}
+	case "user":
+		if u, ok := m.users[targetID]; ok {
+			ownerID = u.v.ID
+		}
+	}
+	if ownerID == "" {
+		return "", ErrNotFound
+	}
+	return ownerID, nil
+}
+
+func (r memoryReports) Create(ctx context.Context, rep Report) (string, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	// システムによる自動通報（ReporterID が nil）は何件でも作れる
+	if rep.ReporterID != nil {
+		if _, ok := r.m.users[*rep.ReporterID]; !ok {
+			return "", ErrMissingReference
+		}
+		for _, existing := range r.m.reports {
+			e := existing.v
+			if e.ReporterID != nil && *e.ReporterID == *rep.ReporterID &&
+				e.TargetType == rep.TargetType && e.TargetID == rep.TargetID && reportOpen(e) {
+				// 未処理の通報が既にある
+				return "", ErrConflict
+			}
+		}
+	}
+
+	rep.ID = newID()
+	rep.ReporterID = copyString(rep.ReporterID)
+	rep.Status = "open"
+	rep.Action = nil
+	rep.HandledBy = nil
+	rep.ResolutionNote = ""
+	rep.CreatedAt = r.m.now()
+	r.m.reports[rep.ID] = &memoryRow[Report]{v: rep, seq: r.m.next()}
+	return rep.ID, nil
+}
+
+func (r memoryReports) List(ctx context.Context, statuses []string, limit int) ([]Report, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	wanted := map[string]bool{}
+	for _, s := range statuses {
+		wanted[s] = true
+	}
+
+	var reports []Report
+	for _, row := range oldest(r.m.reports) {
+		if len(reports) == limit {
+			break
+		}
+		if !wanted[row.v.Status] {
+			continue
+		}
+
+		rep := row.v
+		for _, other := range r.m.reports {
+			if other.v.TargetType == rep.TargetType && other.v.TargetID == rep.TargetID && reportOpen(other.v) {
+				rep.ReportCount++
+			}
+		}
+		reports = append(reports, rep)
+	}
+	return reports, nil
+}
+
+func (r memoryReports) Triage(ctx context.Context, id, adminID string) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	row, ok := r.m.reports[id]
+	if !ok || row.v.Status != "open" {
+		return ErrNotFound
+	}
+	row.v.Status = "triaged"
+	row.v.HandledBy = &adminID
+	return nil
+}
+
+func (r memoryReports) Resolve(ctx context.Context, id, adminID, note string, decide func(Report) (Moderation, error)) error {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	row, ok := r.m.reports[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	mod, err := decide(Report{ID: id, TargetType: row.v.TargetType, TargetID: row.v.TargetID, Status: row.v.Status})
+	if err != nil {
+		return err
+	}
+
+	// レビューや作品の通報でも、その持ち主を停止できる
+	// 持ち主がいなければ何も変えずに失敗する
+	var owner *memoryRow[memoryUser]
+	if mod.SuspendOwner {
+		ownerID, err := r.m.targetOwner(row.v.TargetType, row.v.TargetID)
+		if err != nil {
+			return ErrMissingReference
+		}
+		owner = r.m.users[ownerID]
+	}
+
+	now := r.m.now()
+	if mod.HideTarget {
+		switch row.v.TargetType {
+		case "review":
+			if rv, ok := r.m.reviews[row.v.TargetID]; ok && rv.v.hiddenAt == nil {
+				rv.v.hiddenAt = &now
+			}
+		case "work":
+			if w, ok := r.m.works[row.v.TargetID]; ok && w.v.HiddenAt == nil {
+				w.v.HiddenAt = &now
+			}
+		}
+	}
+
+	if owner != nil && owner.v.SuspendedAt == nil {
+		owner.v.SuspendedAt = &now
+	}
+
+	row.v.Status = mod.Status
+	row.v.Action = &mod.Action
+	row.v.HandledBy = &adminID
+	row.v.ResolutionNote = note
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type memoryStats struct{ m *memory }
+
+func (r memoryStats) Summary(ctx context.Context, userID string) (StatsSummary, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var s StatsSummary
+	for _, match := range r.m.matches {
+		if _, _, ok := match.v.Partner(userID); ok {
+			s.Matches++
+		}
+	}
+
+	var total float64
+	responses := 0
+	for _, mine := range r.m.reviews {
+		if mine.v.ToUserID == userID {
+			s.ReviewsReceived++
+		}
+		if mine.v.FromUserID != userID {
+			continue
+		}
+		s.ReviewsSent++
+
+		// 相手から受け取ったあとに返したレビュー
+		theirs, ok := r.m.findReview(mine.v.MatchID, mine.v.ToUserID)
+		if ok && mine.v.CreatedAt.After(theirs.CreatedAt) {
+			total += mine.v.CreatedAt.Sub(theirs.CreatedAt).Seconds()
+			responses++
+		}
+	}
+	if responses > 0 {
+		avg := total / float64(responses)
+		s.AvgResponseSeconds = &avg
+	}
+	return s, nil
+}
+
+func (r memoryStats) WorkSwipes(ctx context.Context, userID string) ([]WorkSwipes, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var works []WorkSwipes
+	for _, w := range newest(r.m.works) {
+		if w.v.UserID != userID {
+			continue
+		}
+		ws := WorkSwipes{WorkID: w.v.ID, Title: w.v.Title}
+		for key, s := range r.m.swipes {
+			switch {
+			case key.toWorkID != w.v.ID:
+			case s.v.isLike:
+				ws.Likes++
+			default:
+				ws.Passes++
+			}
+		}
+		works = append(works, ws)
+	}
+	return works, nil
+}
+
+func (r memoryStats) Daily(ctx context.Context, userID string, days int) ([]DailyCounts, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	now := r.m.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	daily := make([]DailyCounts, days)
+	for i := range daily {
+		daily[i].Day = today.AddDate(0, 0, i-days+1)
+	}
+	// day は at（UTC）の日の集計（範囲外なら nil）
+	day := func(at time.Time) *DailyCounts {
+		at = at.UTC()
+		i := days - 1 - int(today.Sub(time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)).Hours()/24)
+		if i < 0 || i >= days {
+			return nil
+		}
+		return &daily[i]
+	}
+
+	for _, s := range r.m.swipes {
+		d := day(s.v.createdAt)
+		switch {
+		case s.v.toWorkUserID != userID || d == nil:
+		case s.v.isLike:
+			d.Likes++
+		default:
+			d.Passes++
+		}
+	}
+	for _, match := range r.m.matches {
+		if _, _, ok := match.v.Partner(userID); ok {
+			if d := day(match.v.CreatedAt); d != nil {
+				d.Matches++
+			}
+		}
+	}
+	for _, rv := range r.m.reviews {
+		d := day(rv.v.CreatedAt)
+		if d == nil {
+			continue
+		}
+		if rv.v.FromUserID == userID {
+			d.ReviewsSent++
+		}
+		if rv.v.ToUserID == userID {
+			d.ReviewsReceived++
+		}
+	}
+	return daily, nil
+}
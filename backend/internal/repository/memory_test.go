@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newTestUserAndWork(t *testing.T, repos Repositories, email string) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	userID, err := repos.Users.Create(ctx, User{Username: email, Email: email, Password: "dummy"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	path := email + ".png"
+	workID, err := repos.Works.Save(ctx, Work{UserID: userID, Title: "work", ImagePath: &path})
+	if err != nil {
+		t.Fatalf("failed to create work: %v", err)
+	}
+	return userID, workID
+}
+
+func TestMemoryUsersEmailIsCaseInsensitive(t *testing.T) {
+	repos := NewMemory()
+	ctx := context.Background()
+
+	if _, err := repos.Users.Create(ctx, User{Username: "a", Email: "Alice@Example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repos.Users.Create(ctx, User{Username: "a", Email: "alice@example.com"}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+
+	u, err := repos.Users.FindByEmail(ctx, "ALICE@example.com")
+	if err != nil || u.Email != "alice@example.com" {
+		t.Fatalf("unexpected user %+v, err %v", u, err)
+	}
+}
+
+func TestMemorySwipes(t *testing.T) {
+	repos := NewMemory()
+	ctx := context.Background()
+
+	alice, aliceWork := newTestUserAndWork(t, repos, "alice@example.com")
+	bob, bobWork := newTestUserAndWork(t, repos, "bob@example.com")
+
+	if err := repos.Swipes.Save(ctx, alice, aliceWork, true); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("swiping own work: expected ErrNotFound, got %v", err)
+	}
+	if err := repos.Swipes.Save(ctx, "00000000-0000-4000-8000-000000000000", bobWork, true); !errors.Is(err, ErrMissingReference) {
+		t.Fatalf("unknown user: expected ErrMissingReference, got %v", err)
+	}
+
+	// パスしたあとにいいねし直せる
+	if err := repos.Swipes.Save(ctx, alice, bobWork, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repos.Swipes.FindLike(ctx, alice, bob); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("pass should not count as like, got %v", err)
+	}
+	if err := repos.Swipes.Save(ctx, alice, bobWork, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workID, err := repos.Swipes.FindLike(ctx, alice, bob); err != nil || workID != bobWork {
+		t.Fatalf("expected like on %s, got %s (%v)", bobWork, workID, err)
+	}
+
+	ids, _ := repos.Works.UnswipedIDs(ctx, alice)
+	if len(ids) != 0 {
+		t.Fatalf("swiped and own works should be excluded, got %v", ids)
+	}
+}
+
+func TestMemoryMatchesAreOrderedAndUnique(t *testing.T) {
+	repos := NewMemory()
+	ctx := context.Background()
+
+	alice, aliceWork := newTestUserAndWork(t, repos, "alice@example.com")
+	bob, bobWork := newTestUserAndWork(t, repos, "bob@example.com")
+
+	m, err := repos.Matches.Create(ctx, Match{User1ID: alice, User2ID: bob, Work1ID: aliceWork, Work2ID: bobWork})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.User1ID > m.User2ID {
+		t.Fatalf("users should be ordered: %+v", m)
+	}
+
+	_, err = repos.Matches.Create(ctx, Match{User1ID: bob, User2ID: alice, Work1ID: bobWork, Work2ID: aliceWork})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestMemoryReviewsUnlockWhenBothReviewed(t *testing.T) {
+	repos := NewMemory()
+	ctx := context.Background()
+
+	alice, aliceWork := newTestUserAndWork(t, repos, "alice@example.com")
+	bob, bobWork := newTestUserAndWork(t, repos, "bob@example.com")
+	m, _ := repos.Matches.Create(ctx, Match{User1ID: alice, User2ID: bob, Work1ID: aliceWork, Work2ID: bobWork})
+
+	if _, err := repos.Reviews.Create(ctx, Review{
+		MatchID: m.ID, FromUserID: alice, ToUserID: bob, WorkID: bobWork, Comment: "from alice",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repos.Reviews.Create(ctx, Review{
+		MatchID: m.ID, FromUserID: alice, ToUserID: bob, WorkID: bobWork, Comment: "again",
+	}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+
+	// bob はまだお返ししていないので読めない
+	received, _ := repos.Reviews.Received(ctx, bob, false)
+	if len(received) != 0 {
+		t.Fatalf("locked review should be hidden, got %+v", received)
+	}
+	received, _ = repos.Reviews.Received(ctx, bob, true)
+	if len(received) != 1 || !received[0].Locked || received[0].Comment != "" {
+		t.Fatalf("expected locked teaser without body, got %+v", received)
+	}
+
+	if _, err := repos.Reviews.Create(ctx, Review{
+		MatchID: m.ID, FromUserID: bob, ToUserID: alice, WorkID: aliceWork, Comment: "from bob",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received, _ = repos.Reviews.Received(ctx, bob, false)
+	if len(received) != 1 || received[0].Locked || received[0].Comment != "from alice" {
+		t.Fatalf("expected unlocked review, got %+v", received)
+	}
+
+	statuses, _ := repos.Reviews.Statuses(ctx, alice)
+	if len(statuses) != 1 || !statuses[0].HasSentReview || statuses[0].ReceivedReviewID == nil {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+}
+
+func TestMemoryPurgeAnonymizesSentReviews(t *testing.T) {
+	repos := NewMemory()
+	ctx := context.Background()
+
+	alice, aliceWork := newTestUserAndWork(t, repos, "alice@example.com")
+	bob, bobWork := newTestUserAndWork(t, repos, "bob@example.com")
+	m, _ := repos.Matches.Create(ctx, Match{User1ID: alice, User2ID: bob, Work1ID: aliceWork, Work2ID: bobWork})
+	for _, rv := range []Review{
+		{MatchID: m.ID, FromUserID: alice, ToUserID: bob, WorkID: bobWork, Comment: "from alice"},
+		{MatchID: m.ID, FromUserID: bob, ToUserID: alice, WorkID: aliceWork, Comment: "from bob"},
+	} {
+		if _, err := repos.Reviews.Create(ctx, rv); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 退会を申請していなければ削除しない
+	if _, err := repos.Accounts.Purge(ctx, alice); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := repos.Accounts.ScheduleDeletion(ctx, alice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objects, err := repos.Accounts.Purge(ctx, alice)
+	if err != nil || len(objects) != 1 || objects[0] != "alice@example.com.png" {
+		t.Fatalf("unexpected objects %v, err %v", objects, err)
+	}
+
+	// alice が書いたレビューは匿名で残る
+	received, _ := repos.Reviews.Received(ctx, bob, false)
+	if len(received) != 1 || received[0].FromUserID != "" || received[0].Comment != "from alice" {
+		t.Fatalf("expected anonymized review, got %+v", received)
+	}
+	if _, err := repos.Users.Get(ctx, alice); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
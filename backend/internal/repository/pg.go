@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/p2hacks2025/pre-12/backend/internal/db"
+)
+
+// NewPG は Postgres を使うリポジトリ一式を返す
+func NewPG(pool *pgxpool.Pool) Repositories {
+	return Repositories{
+		Users:         NewPGUsers(pool),
+		Works:         NewPGWorks(pool),
+		Swipes:        NewPGSwipes(pool),
+		Matches:       NewPGMatches(pool),
+		Reviews:       NewPGReviews(pool),
+		Accounts:      NewPGAccounts(pool),
+		Handles:       NewPGHandles(pool),
+		Blocks:        NewPGBlocks(pool),
+		Devices:       NewPGDevices(pool),
+		Notifications: NewPGNotifications(pool),
+		Messages:      NewPGMessages(pool),
+		Reports:       NewPGReports(pool),
+		Stats:         NewPGStats(pool),
+	}
+}
+
+// pgError は pgx のエラーをリポジトリのエラーに読み替える
+func pgError(err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrNotFound
+	case db.IsUniqueViolation(err):
+		return ErrConflict
+	case db.IsForeignKeyViolation(err):
+		return ErrMissingReference
+	}
+	return err
+}
+
+// cursorArgs は SQL に渡す created_at と id（cursor がなければどちらも null）
+func cursorArgs(c *Cursor) (*time.Time, *string) {
+	if c == nil {
+		return nil, nil
+	}
+	return &c.CreatedAt, &c.ID
+}
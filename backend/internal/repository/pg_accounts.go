@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGAccounts は users と関連テーブルを使う Accounts 実装
+type PGAccounts struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGAccounts(pool *pgxpool.Pool) *PGAccounts {
+	return &PGAccounts{pool: pool}
+}
+
+func (r *PGAccounts) ScheduleDeletion(ctx context.Context, userID string) (time.Time, error) {
+	var deletedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		UPDATE public.users
+		SET deleted_at = COALESCE(deleted_at, now())
+		WHERE id = $1
+		RETURNING deleted_at
+	`, userID).Scan(&deletedAt)
+	return deletedAt, pgError(err)
+}
+
+func (r *PGAccounts) CancelDeletion(ctx context.Context, userID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.users
+		SET deleted_at = NULL
+		WHERE id = $1
+		  AND deleted_at IS NOT NULL
+	`, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGAccounts) DueForPurge(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id
+		FROM public.users
+		WHERE deleted_at IS NOT NULL
+		  AND deleted_at <= $1
+	`, before)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// Purge はユーザーを完全に削除する
+// レビュー以外のデータは外部キーの cascade で消える
+func (r *PGAccounts) Purge(ctx context.Context, userID string) ([]string, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var iconPath *string
+	err = tx.QueryRow(ctx, `
+		SELECT icon_path
+		FROM public.users
+		WHERE id = $1
+		  AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`, userID).Scan(&iconPath)
+	if err != nil {
+		// 取り消された・既に削除された
+		return nil, pgError(err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT image_path
+		FROM public.works
+		WHERE user_id = $1
+		  AND image_path IS NOT NULL
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	if iconPath != nil {
+		objects = append(objects, *iconPath)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM public.review_revisions
+		WHERE review_id IN (
+		  SELECT id FROM public.reviews WHERE from_user_id = $1
+		)
+	`, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public.reviews
+		SET anonymized_at = now()
+		WHERE from_user_id = $1
+	`, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM public.users WHERE id = $1`, userID); err != nil {
+		return nil, err
+	}
+
+	return objects, tx.Commit(ctx)
+}
+
+// エクスポートする各データの SQL（$1 は本人の ID、古い順）
+const (
+	exportWorksQuery = `
+		SELECT id, title, description, image_path, created_at
+		FROM public.works
+		WHERE user_id = $1
+		ORDER BY created_at`
+	exportSwipesQuery = `
+		SELECT id, to_work_id, is_like, created_at
+		FROM public.swipes
+		WHERE from_user_id = $1
+		ORDER BY created_at`
+	exportMatchesQuery = `
+		SELECT
+		  id,
+		  CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END,
+		  CASE WHEN user1_id = $1 THEN work1_id ELSE work2_id END,
+		  CASE WHEN user1_id = $1 THEN work2_id ELSE work1_id END,
+		  created_at
+		FROM public.matches
+		WHERE $1 IN (user1_id, user2_id)
+		ORDER BY created_at`
+	exportSentReviewsQuery = `
+		SELECT id, match_id, to_user_id, work_id, comment, sections, edited_at, read_at, created_at
+		FROM public.reviews
+		WHERE from_user_id = $1
+		ORDER BY created_at`
+	// 受け取ったレビューは Give-to-Get を崩さないよう、読めるものだけを含める
+	exportReceivedReviewsQuery = `
+		SELECT
+		  r.id, r.match_id, r.from_user_id, r.work_id, r.comment, r.sections, r.created_at,
+		  COALESCE((
+		    SELECT array_agg(rr.reaction ORDER BY rr.created_at)
+		    FROM public.review_reactions rr
+		    WHERE rr.review_id = r.id
+		  ), '{}')
+		FROM public.reviews r
+		WHERE r.to_user_id = $1
+		  AND ` + ReviewUnlockedCondition + `
+		ORDER BY r.created_at`
+)
+
+func (r *PGAccounts) Export(ctx context.Context, userID string) (Export, error) {
+	var e Export
+
+	p := &e.Profile
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, username, handle, email, bio, icon_path, deleted_at
+		FROM public.users
+		WHERE id = $1
+	`, userID).Scan(&p.ID, &p.Username, &p.Handle, &p.Email, &p.Bio, &p.IconPath, &p.DeletedAt)
+	if err != nil {
+		return Export{}, pgError(err)
+	}
+
+	if e.Works, err = exportRows(ctx, r.pool, exportWorksQuery, userID, func(row pgx.Row, w *ExportWork) error {
+		return row.Scan(&w.ID, &w.Title, &w.Description, &w.ImagePath, &w.CreatedAt)
+	}); err != nil {
+		return Export{}, err
+	}
+	if e.Swipes, err = exportRows(ctx, r.pool, exportSwipesQuery, userID, func(row pgx.Row, s *ExportSwipe) error {
+		return row.Scan(&s.ID, &s.ToWorkID, &s.IsLike, &s.CreatedAt)
+	}); err != nil {
+		return Export{}, err
+	}
+	if e.Matches, err = exportRows(ctx, r.pool, exportMatchesQuery, userID, func(row pgx.Row, m *ExportMatch) error {
+		return row.Scan(&m.ID, &m.PartnerID, &m.MyWorkID, &m.PartnerWorkID, &m.CreatedAt)
+	}); err != nil {
+		return Export{}, err
+	}
+	if e.ReviewsSent, err = exportRows(ctx, r.pool, exportSentReviewsQuery, userID, func(row pgx.Row, rv *ExportSentReview) error {
+		return row.Scan(&rv.ID, &rv.MatchID, &rv.ToUserID, &rv.WorkID, &rv.Comment, &rv.Sections, &rv.EditedAt, &rv.ReadAt, &rv.CreatedAt)
+	}); err != nil {
+		return Export{}, err
+	}
+	if e.ReviewsReceived, err = exportRows(ctx, r.pool, exportReceivedReviewsQuery, userID, func(row pgx.Row, rv *ExportReceivedReview) error {
+		return row.Scan(&rv.ID, &rv.MatchID, &rv.FromUserID, &rv.WorkID, &rv.Comment, &rv.Sections, &rv.CreatedAt, &rv.MyReactions)
+	}); err != nil {
+		return Export{}, err
+	}
+
+	return e, nil
+}
+
+// exportRows は query の結果を scan で読み込む（0 件でも空のスライスを返す）
+func exportRows[T any](ctx context.Context, pool *pgxpool.Pool, query, userID string, scan func(pgx.Row, *T) error) ([]T, error) {
+	rows, err := pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []T{}
+	for rows.Next() {
+		var v T
+		if err := scan(rows, &v); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
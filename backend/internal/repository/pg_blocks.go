@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGBlocks は user_blocks テーブルを使う Blocks 実装
+type PGBlocks struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGBlocks(pool *pgxpool.Pool) *PGBlocks {
+	return &PGBlocks{pool: pool}
+}
+
+func (r *PGBlocks) Block(ctx context.Context, blockerID, blockedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public.user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, blockerID, blockedID)
+	return pgError(err)
+}
+
+func (r *PGBlocks) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM public.user_blocks
+		WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	return err
+}
+
+func (r *PGBlocks) List(ctx context.Context, blockerID string) ([]BlockedUser, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT u.id, u.username, u.icon_path, b.created_at
+		FROM public.user_blocks b
+		JOIN public.users u
+		  ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC
+	`, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []BlockedUser
+	for rows.Next() {
+		var u BlockedUser
+		if err := rows.Scan(&u.UserID, &u.Username, &u.IconPath, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (r *PGBlocks) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+	var blocked bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+		  SELECT 1
+		  FROM public.user_blocks
+		  WHERE (blocker_id = $1 AND blocked_id = $2)
+		     OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`, userA, userB).Scan(&blocked)
+	return blocked, err
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+)
+
+// PGDevices は device_tokens テーブルを使う Devices 実装
+type PGDevices struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGDevices(pool *pgxpool.Pool) *PGDevices {
+	return &PGDevices{pool: pool}
+}
+
+func (r *PGDevices) Register(ctx context.Context, userID string, d notify.Device) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public.device_tokens (user_id, token, platform)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+		    platform = EXCLUDED.platform,
+		    last_seen_at = now()
+	`, userID, d.Token, d.Platform)
+	return pgError(err)
+}
+
+func (r *PGDevices) Unregister(ctx context.Context, userID, token string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM public.device_tokens
+		WHERE user_id = $1 AND token = $2
+	`, userID, token)
+	return err
+}
+
+func (r *PGDevices) RemoveForUser(ctx context.Context, userID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM public.device_tokens WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *PGDevices) DevicesForUser(ctx context.Context, userID string) ([]notify.Device, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT token, platform
+		FROM public.device_tokens
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []notify.Device
+	for rows.Next() {
+		var d notify.Device
+		if err := rows.Scan(&d.Token, &d.Platform); err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+	return devices, rows.Err()
+}
+
+func (r *PGDevices) RemoveToken(ctx context.Context, token string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM public.device_tokens WHERE token = $1`, token)
+	return err
+}
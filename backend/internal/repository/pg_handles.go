@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGHandles は users.handle と handle_history を使う Handles 実装
+type PGHandles struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGHandles(pool *pgxpool.Pool) *PGHandles {
+	return &PGHandles{pool: pool}
+}
+
+func (r *PGHandles) Change(ctx context.Context, userID, handle string, heldSince time.Time, check func(current *string, changedAt *time.Time) error) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		current   *string
+		changedAt *time.Time
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT handle, handle_changed_at
+		FROM public.users
+		WHERE id = $1
+		FOR UPDATE
+	`, userID).Scan(&current, &changedAt)
+	if err != nil {
+		return false, pgError(err)
+	}
+
+	if current != nil && *current == handle {
+		return false, nil
+	}
+	if err := check(current, changedAt); err != nil {
+		return false, err
+	}
+
+	// 他の人の以前のハンドルは一定期間使えない（自分の以前のハンドルには戻せる）
+	var heldBy string
+	err = tx.QueryRow(ctx, `
+		SELECT user_id
+		FROM public.handle_history
+		WHERE handle = $1
+		  AND released_at > $2
+	`, handle, heldSince).Scan(&heldBy)
+	if err == nil && heldBy != userID {
+		return false, ErrConflict
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM public.handle_history WHERE handle = $1`, handle); err != nil {
+		return false, err
+	}
+
+	if current != nil {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO public.handle_history (handle, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (handle) DO UPDATE
+			SET user_id = EXCLUDED.user_id,
+			    released_at = now()
+		`, *current, userID); err != nil {
+			return false, err
+		}
+	}
+
+	// 初回の設定は変更回数に数えない
+	// 他の人が使っていれば一意制約で ErrConflict になる
+	if _, err := tx.Exec(ctx, `
+		UPDATE public.users
+		SET handle = $2,
+		    handle_changed_at = CASE WHEN handle IS NULL THEN handle_changed_at ELSE now() END
+		WHERE id = $1
+	`, userID, handle); err != nil {
+		return false, pgError(err)
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+func (r *PGHandles) Find(ctx context.Context, handle string) (string, error) {
+	var userID string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id FROM public.users
+		WHERE handle = $1
+		  AND suspended_at IS NULL
+		  AND deleted_at IS NULL
+	`, handle).Scan(&userID)
+	return userID, pgError(err)
+}
+
+func (r *PGHandles) FindPrevious(ctx context.Context, handle string, since time.Time) (string, error) {
+	var current string
+	err := r.pool.QueryRow(ctx, `
+		SELECT u.handle
+		FROM public.handle_history hh
+		JOIN public.users u
+		  ON u.id = hh.user_id
+		WHERE hh.handle = $1
+		  AND hh.released_at > $2
+		  AND u.handle IS NOT NULL
+		  AND u.suspended_at IS NULL
+		  AND u.deleted_at IS NULL
+	`, handle, since).Scan(&current)
+	return current, pgError(err)
+}
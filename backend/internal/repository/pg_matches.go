@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGMatches は matches テーブルを使う Matches 実装
+type PGMatches struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGMatches(pool *pgxpool.Pool) *PGMatches {
+	return &PGMatches{pool: pool}
+}
+
+func (r *PGMatches) Create(ctx context.Context, m Match) (Match, error) {
+	m = m.ordered()
+
+	// 既にマッチ済みなら RETURNING が空になる
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO matches (user1_id, user2_id, work1_id, work2_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at
+	`, m.User1ID, m.User2ID, m.Work1ID, m.Work2ID).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		if err = pgError(err); err == ErrNotFound {
+			return Match{}, ErrConflict
+		}
+		return Match{}, err
+	}
+	return m, nil
+}
+
+func (r *PGMatches) Get(ctx context.Context, id string) (Match, error) {
+	var m Match
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, user1_id, user2_id, work1_id, work2_id, created_at
+		FROM public.matches
+		WHERE id = $1
+	`, id).Scan(&m.ID, &m.User1ID, &m.User2ID, &m.Work1ID, &m.Work2ID, &m.CreatedAt)
+	return m, pgError(err)
+}
+
+func (r *PGMatches) ListForUser(ctx context.Context, userID string) ([]MatchSummary, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  m.id AS match_id,
+		  u.id AS user_id,
+		  u.username,
+		  u.icon_path,
+		  w.image_path AS work_image_path,
+		  w.title AS work_title,
+		  EXISTS (
+		    SELECT 1
+		    FROM public.reviews r
+		    WHERE r.match_id = m.id
+		      AND r.from_user_id = $1
+		  ) AS is_reviewed
+		FROM public.matches m
+		JOIN public.users u
+		  ON u.id = CASE
+		    WHEN m.user1_id = $1 THEN m.user2_id
+		    ELSE m.user1_id
+		  END
+		JOIN public.works w
+		  ON w.id = CASE
+		    WHEN m.user1_id = $1 THEN m.work2_id
+		    ELSE m.work1_id
+		  END
+		WHERE $1 IN (m.user1_id, m.user2_id)
+		  AND u.suspended_at IS NULL
+		  AND u.deleted_at IS NULL
+		  AND w.hidden_at IS NULL
+		ORDER BY m.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []MatchSummary
+	for rows.Next() {
+		var m MatchSummary
+		if err := rows.Scan(
+			&m.MatchID,
+			&m.UserID,
+			&m.Username,
+			&m.IconPath,
+			&m.WorkImagePath,
+			&m.WorkTitle,
+			&m.IsReviewed,
+		); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
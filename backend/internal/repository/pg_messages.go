@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGMessages は messages テーブルを使う Messages 実装
+type PGMessages struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGMessages(pool *pgxpool.Pool) *PGMessages {
+	return &PGMessages{pool: pool}
+}
+
+func (r *PGMessages) List(ctx context.Context, matchID string, after *Cursor, limit int) ([]Message, error) {
+	afterAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, match_id, sender_id, body, read_at, created_at
+		FROM public.messages
+		WHERE match_id = $1
+		  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`, matchID, afterAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.MatchID, &m.SenderID, &m.Body, &m.ReadAt, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+func (r *PGMessages) Create(ctx context.Context, m Message) (Message, error) {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO public.messages (match_id, sender_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, m.MatchID, m.SenderID, m.Body).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		return Message{}, pgError(err)
+	}
+	return m, nil
+}
+
+func (r *PGMessages) MarkRead(ctx context.Context, matchID, senderID string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.messages
+		SET read_at = now()
+		WHERE match_id = $1
+		  AND sender_id = $2
+		  AND read_at IS NULL
+	`, matchID, senderID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGNotifications は notifications と notification_preferences テーブルを使う Notifications 実装
+type PGNotifications struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGNotifications(pool *pgxpool.Pool) *PGNotifications {
+	return &PGNotifications{pool: pool}
+}
+
+func (r *PGNotifications) Create(ctx context.Context, n Notification) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO public.notifications (user_id, type, actor_user_id, match_id, review_id)
+		SELECT $1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid
+		WHERE NOT EXISTS (
+		  SELECT 1
+		  FROM public.notification_preferences p
+		  WHERE p.user_id = $1
+		    AND p.type = $2
+		    AND p.enabled = false
+		)
+	`, n.UserID, n.Type, n.ActorUserID, n.MatchID, n.ReviewID)
+	if err != nil {
+		return false, pgError(err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// RemindUnreviewed は同じマッチへのリマインドを一意インデックスで 1 回に抑える
+func (r *PGNotifications) RemindUnreviewed(ctx context.Context, typ string, from, to time.Time) ([]Notification, error) {
+	rows, err := r.pool.Query(ctx, `
+		INSERT INTO public.notifications (user_id, type, actor_user_id, match_id)
+		SELECT t.user_id, $1, t.partner_id, t.match_id
+		FROM (
+		  SELECT m.id AS match_id, m.user1_id AS user_id, m.user2_id AS partner_id, m.created_at
+		  FROM public.matches m
+		  UNION ALL
+		  SELECT m.id, m.user2_id, m.user1_id, m.created_at
+		  FROM public.matches m
+		) t
+		WHERE t.created_at <= $2
+		  AND t.created_at > $3
+		  AND NOT EXISTS (
+		    SELECT 1
+		    FROM public.reviews r
+		    WHERE r.match_id = t.match_id
+		      AND r.from_user_id = t.user_id
+		  )
+		  AND NOT EXISTS (
+		    SELECT 1
+		    FROM public.notification_preferences p
+		    WHERE p.user_id = t.user_id
+		      AND p.type = $1
+		      AND p.enabled = false
+		  )
+		ON CONFLICT DO NOTHING
+		RETURNING user_id, actor_user_id, match_id
+	`, typ, to, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var created []Notification
+	for rows.Next() {
+		n := Notification{Type: typ}
+		if err := rows.Scan(&n.UserID, &n.ActorUserID, &n.MatchID); err != nil {
+			return nil, err
+		}
+		created = append(created, n)
+	}
+	return created, rows.Err()
+}
+
+func (r *PGNotifications) List(ctx context.Context, userID string, after *Cursor, unreadOnly bool, limit int) ([]InboxNotification, error) {
+	afterAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  n.id,
+		  n.type,
+		  n.actor_user_id,
+		  u.username,
+		  u.icon_path,
+		  n.match_id,
+		  n.review_id,
+		  n.read_at IS NOT NULL AS is_read,
+		  n.created_at
+		FROM public.notifications n
+		LEFT JOIN public.users u
+		  ON u.id = n.actor_user_id
+		WHERE n.user_id = $1
+		  AND ($2::timestamptz IS NULL OR (n.created_at, n.id) < ($2, $3::uuid))
+		  AND ($4 = false OR n.read_at IS NULL)
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $5
+	`, userID, afterAt, afterID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []InboxNotification
+	for rows.Next() {
+		var n InboxNotification
+		if err := rows.Scan(
+			&n.ID,
+			&n.Type,
+			&n.ActorUserID,
+			&n.ActorUsername,
+			&n.ActorIconPath,
+			&n.MatchID,
+			&n.ReviewID,
+			&n.IsRead,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+func (r *PGNotifications) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM public.notifications
+		WHERE user_id = $1
+		  AND read_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *PGNotifications) MarkRead(ctx context.Context, userID string, ids []string, all bool) (int64, error) {
+	// 他人の通知は user_id 条件で弾く
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.notifications
+		SET read_at = now()
+		WHERE user_id = $1
+		  AND read_at IS NULL
+		  AND ($2 OR id = ANY($3::uuid[]))
+	`, userID, all, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PGNotifications) Preferences(ctx context.Context, userID string) (map[string]bool, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT type, enabled
+		FROM public.notification_preferences
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	saved := map[string]bool{}
+	for rows.Next() {
+		var t string
+		var enabled bool
+		if err := rows.Scan(&t, &enabled); err != nil {
+			return nil, err
+		}
+		saved[t] = enabled
+	}
+	return saved, rows.Err()
+}
+
+func (r *PGNotifications) SetPreference(ctx context.Context, userID, typ string, enabled bool) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO public.notification_preferences (user_id, type, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, type) DO UPDATE
+		SET enabled = EXCLUDED.enabled, updated_at = now()
+	`, userID, typ, enabled)
+	return pgError(err)
+}
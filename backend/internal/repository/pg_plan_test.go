@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/p2hacks2025/pre-12/backend/internal/db"
+)
+
+// planNode は EXPLAIN (FORMAT JSON) の 1 ノード
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	Alias        string     `json:"Alias"`
+	Plans        []planNode `json:"Plans"`
+}
+
+// seqScans は plan の中で relation を Seq Scan しているノードの別名を返す
+func seqScans(plan planNode, relation string) []string {
+	var found []string
+	if plan.NodeType == "Seq Scan" && plan.RelationName == relation {
+		found = append(found, plan.Alias)
+	}
+	for _, p := range plan.Plans {
+		found = append(found, seqScans(p, relation)...)
+	}
+	return found
+}
+
+// seedPlanData はトランザクションの中に、プランナーがインデックスを選ぶ程度の量のデータを作る
+// 作ったユーザーのうちの 1 人とその相手を返す
+func seedPlanData(t *testing.T, ctx context.Context, tx pgx.Tx) (string, string) {
+	t.Helper()
+
+	tag := fmt.Sprintf("plan_%d", time.Now().UnixNano())
+	steps := []string{
+		// 300 ユーザー × 3 作品
+		`INSERT INTO public.users (username, email, password)
+		 SELECT 'plan', $1::text || '_' || i || '@example.com', 'x'
+		 FROM generate_series(1, 300) i`,
+		`INSERT INTO public.works (user_id, image_path, title)
+		 SELECT u.id, '/plan/' || u.id || '_' || i || '.png', 'plan'
+		 FROM public.users u, generate_series(1, 3) i
+		 WHERE u.email LIKE $1::text || '\_%'`,
+		// 他人の作品の 3% ほどをスワイプ
+		`INSERT INTO public.swipes (from_user_id, to_work_id, to_work_user_id, is_like)
+		 SELECT u.id, w.id, w.user_id, random() < 0.5
+		 FROM public.users u
+		 JOIN public.works w ON w.user_id <> u.id
+		 JOIN public.users o ON o.id = w.user_id
+		 WHERE u.email LIKE $1::text || '\_%'
+		   AND o.email LIKE $1::text || '\_%'
+		   AND random() < 0.03`,
+		// 隣り合うユーザー同士をマッチさせ、お互いにレビューする
+		`WITH numbered AS (
+		   SELECT id, row_number() OVER (ORDER BY id) AS n
+		   FROM public.users
+		   WHERE email LIKE $1::text || '\_%'
+		 )
+		 INSERT INTO public.matches (user1_id, user2_id, work1_id, work2_id)
+		 SELECT a.id, b.id,
+		        (SELECT id FROM public.works WHERE user_id = a.id LIMIT 1),
+		        (SELECT id FROM public.works WHERE user_id = b.id LIMIT 1)
+		 FROM numbered a
+		 JOIN numbered b ON b.n = a.n + 1`,
+		`INSERT INTO public.reviews (match_id, from_user_id, to_user_id, work_id, comment)
+		 SELECT m.id, x.from_id, x.to_id, x.work_id, 'plan'
+		 FROM public.matches m
+		 JOIN public.users u ON u.id = m.user1_id
+		 CROSS JOIN LATERAL (
+		   VALUES (m.user1_id, m.user2_id, m.work2_id), (m.user2_id, m.user1_id, m.work1_id)
+		 ) x (from_id, to_id, work_id)
+		 WHERE u.email LIKE $1::text || '\_%'`,
+	}
+	for _, sql := range steps {
+		if _, err := tx.Exec(ctx, sql, tag); err != nil {
+			t.Fatalf("failed to seed: %v", err)
+		}
+	}
+
+	for _, table := range []string{"users", "works", "swipes", "matches", "reviews"} {
+		if _, err := tx.Exec(ctx, "ANALYZE public."+table); err != nil {
+			t.Fatalf("failed to analyze %s: %v", table, err)
+		}
+	}
+
+	var userID, partnerID string
+	err := tx.QueryRow(ctx, `
+		SELECT m.user1_id, m.user2_id
+		FROM public.matches m
+		JOIN public.users u ON u.id = m.user1_id
+		WHERE u.email LIKE $1::text || '\_%'
+		LIMIT 1
+	`, tag).Scan(&userID, &partnerID)
+	if err != nil {
+		t.Fatalf("failed to pick seeded user: %v", err)
+	}
+	return userID, partnerID
+}
+
+// よく使うクエリがデータが増えても全件走査にならないことを確かめる
+// DATABASE_URL の Postgres にマイグレーションを適用してから実行する（データはロールバックする）
+func TestPGQueryPlansUseIndexes(t *testing.T) {
+	if os.Getenv("DATABASE_URL") == "" || os.Getenv("TEST_BACKEND") == "memory" {
+		t.Skip("requires DATABASE_URL")
+	}
+
+	ctx := context.Background()
+	pool, err := db.Connect(ctx, os.Getenv("DATABASE_URL"))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer pool.Close()
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	userID, partnerID := seedPlanData(t, ctx, tx)
+
+	cases := []struct {
+		name     string
+		sql      string
+		args     []any
+		relation string
+	}{
+		{"GetWorks", unswipedWorkIDsQuery, []any{userID}, "swipes"},
+		{"CheckAndCreateMatch", findLikeQuery, []any{userID, partnerID}, "swipes"},
+		{"GetReceivedReviews", receivedReviewsQuery, []any{userID, true}, "reviews"},
+		{"ListWorksByUser", listWorksByUserQuery, []any{userID}, "works"},
+	}
+	for _, c := range cases {
+		var out string
+		if err := tx.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+c.sql, c.args...).Scan(&out); err != nil {
+			t.Fatalf("%s: failed to explain: %v", c.name, err)
+		}
+
+		var plans []struct {
+			Plan planNode `json:"Plan"`
+		}
+		if err := json.Unmarshal([]byte(out), &plans); err != nil || len(plans) == 0 {
+			t.Fatalf("%s: unexpected plan %s: %v", c.name, out, err)
+		}
+		if scans := seqScans(plans[0].Plan, c.relation); len(scans) > 0 {
+			t.Errorf("%s: sequential scan on %s (%v):\n%s", c.name, c.relation, scans, out)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGReports は reports テーブルを使う Reports 実装
+type PGReports struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGReports(pool *pgxpool.Pool) *PGReports {
+	return &PGReports{pool: pool}
+}
+
+// rowQuerier は pgxpool.Pool と pgx.Tx のどちらからでも 1 行読めるようにする
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func (r *PGReports) TargetOwner(ctx context.Context, targetType, targetID string) (string, error) {
+	return targetOwner(ctx, r.pool, targetType, targetID)
+}
+
+// targetOwner は q（トランザクションでもよい）で通報対象の持ち主を読む
+// 退会などで持ち主がいない（null）ときも ErrNotFound
+func targetOwner(ctx context.Context, q rowQuerier, targetType, targetID string) (string, error) {
+	var query string
+	switch targetType {
+	case "review":
+		query = `SELECT from_user_id FROM public.reviews WHERE id = $1`
+	case "work":
+		query = `SELECT user_id FROM public.works WHERE id = $1`
+	case "user":
+		query = `SELECT id FROM public.users WHERE id = $1`
+	default:
+		return "", ErrNotFound
+	}
+
+	var ownerID *string
+	err := q.QueryRow(ctx, query, targetID).Scan(&ownerID)
+	if err != nil {
+		return "", pgError(err)
+	}
+	if ownerID == nil {
+		return "", ErrNotFound
+	}
+	return *ownerID, nil
+}
+
+func (r *PGReports) Create(ctx context.Context, rep Report) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO public.reports (reporter_id, target_type, target_id, reason, note)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`, rep.ReporterID, rep.TargetType, rep.TargetID, rep.Reason, rep.Note).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// 未処理の通報が既にある
+		return "", ErrConflict
+	}
+	return id, pgError(err)
+}
+
+func (r *PGReports) List(ctx context.Context, statuses []string, limit int) ([]Report, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  r.id,
+		  r.reporter_id,
+		  r.target_type,
+		  r.target_id,
+		  r.reason,
+		  r.note,
+		  r.status,
+		  r.action,
+		  r.handled_by,
+		  r.resolution_note,
+		  (
+		    SELECT COUNT(*)
+		    FROM public.reports o
+		    WHERE o.target_type = r.target_type
+		      AND o.target_id = r.target_id
+		      AND o.status IN ('open', 'triaged')
+		  ),
+		  r.created_at
+		FROM public.reports r
+		WHERE r.status = ANY($1)
+		ORDER BY r.created_at ASC
+		LIMIT $2
+	`, statuses, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []Report
+	for rows.Next() {
+		var rep Report
+		if err := rows.Scan(
+			&rep.ID,
+			&rep.ReporterID,
+			&rep.TargetType,
+			&rep.TargetID,
+			&rep.Reason,
+			&rep.Note,
+			&rep.Status,
+			&rep.Action,
+			&rep.HandledBy,
+			&rep.ResolutionNote,
+			&rep.ReportCount,
+			&rep.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		reports = append(reports, rep)
+	}
+	return reports, rows.Err()
+}
+
+func (r *PGReports) Triage(ctx context.Context, id, adminID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.reports
+		SET status = 'triaged',
+		    handled_by = $2,
+		    updated_at = now()
+		WHERE id = $1
+		  AND status = 'open'
+	`, id, adminID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGReports) Resolve(ctx context.Context, id, adminID, note string, decide func(Report) (Moderation, error)) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rep := Report{ID: id}
+	err = tx.QueryRow(ctx, `
+		SELECT target_type, target_id, status
+		FROM public.reports
+		WHERE id = $1
+		FOR UPDATE
+	`, id).Scan(&rep.TargetType, &rep.TargetID, &rep.Status)
+	if err != nil {
+		return pgError(err)
+	}
+
+	mod, err := decide(rep)
+	if err != nil {
+		return err
+	}
+
+	if mod.HideTarget {
+		var query string
+		switch rep.TargetType {
+		case "review":
+			query = `UPDATE public.reviews SET hidden_at = now() WHERE id = $1 AND hidden_at IS NULL`
+		case "work":
+			query = `UPDATE public.works SET hidden_at = now() WHERE id = $1 AND hidden_at IS NULL`
+		}
+		if query != "" {
+			if _, err := tx.Exec(ctx, query, rep.TargetID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if mod.SuspendOwner {
+		// レビューや作品の通報でも、その持ち主を停止できる
+		ownerID, err := targetOwner(ctx, tx, rep.TargetType, rep.TargetID)
+		if errors.Is(err, ErrNotFound) {
+			return ErrMissingReference
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE public.users SET suspended_at = now() WHERE id = $1 AND suspended_at IS NULL
+		`, ownerID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public.reports
+		SET status = $2,
+		    action = $3,
+		    handled_by = $4,
+		    resolution_note = $5,
+		    updated_at = now(),
+		    resolved_at = now()
+		WHERE id = $1
+	`, id, mod.Status, mod.Action, adminID, note); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,403 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReviewUnlockedCondition は受信レビュー r を受信者が読めるかの SQL 条件（Give-to-Get）
+// 同じマッチで受信者も送信者へレビューを送っていれば読める
+// 退会したユーザーの匿名化されたレビューは、もうお返しできないので読める
+const ReviewUnlockedCondition = `(r.anonymized_at IS NOT NULL OR EXISTS (
+	SELECT 1
+	FROM public.reviews my
+	WHERE my.match_id = r.match_id
+	  AND my.from_user_id = r.to_user_id
+	  AND my.to_user_id = r.from_user_id
+))`
+
+// PGReviews は reviews テーブルを使う Reviews 実装
+type PGReviews struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGReviews(pool *pgxpool.Pool) *PGReviews {
+	return &PGReviews{pool: pool}
+}
+
+func (r *PGReviews) Create(ctx context.Context, rv Review) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO public.reviews (
+			match_id,
+			from_user_id,
+			to_user_id,
+			work_id,
+			comment,
+			sections,
+			quality_flags
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, rv.MatchID, rv.FromUserID, rv.ToUserID, rv.WorkID, rv.Comment, rv.Sections, rv.QualityFlags).Scan(&id)
+	return id, pgError(err)
+}
+
+// getReviewQuery はレビューを 1 件返す（匿名化されたレビューのマッチと投稿者、作品は空文字）
+const getReviewQuery = `
+	SELECT
+	  r.id,
+	  COALESCE(r.match_id::text, ''),
+	  COALESCE(r.from_user_id::text, ''),
+	  r.to_user_id,
+	  COALESCE(r.work_id::text, ''),
+	  r.comment,
+	  r.sections,
+	  r.quality_flags,
+	  ` + ReviewUnlockedCondition + `,
+	  r.created_at
+	FROM public.reviews r
+	WHERE r.id = $1
+`
+
+func scanReview(row pgx.Row) (Review, error) {
+	var rv Review
+	err := row.Scan(
+		&rv.ID,
+		&rv.MatchID,
+		&rv.FromUserID,
+		&rv.ToUserID,
+		&rv.WorkID,
+		&rv.Comment,
+		&rv.Sections,
+		&rv.QualityFlags,
+		&rv.Unlocked,
+		&rv.CreatedAt,
+	)
+	return rv, pgError(err)
+}
+
+func (r *PGReviews) Get(ctx context.Context, id string) (Review, error) {
+	return scanReview(r.pool.QueryRow(ctx, getReviewQuery, id))
+}
+
+func (r *PGReviews) FindID(ctx context.Context, matchID, fromUserID string) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id FROM public.reviews
+		WHERE match_id = $1 AND from_user_id = $2
+	`, matchID, fromUserID).Scan(&id)
+	return id, pgError(err)
+}
+
+func (r *PGReviews) RecentComments(ctx context.Context, userID, excludeReviewID string, limit int) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT comment
+		FROM public.reviews
+		WHERE from_user_id = $1
+		  AND id IS DISTINCT FROM NULLIF($2, '')::uuid
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, userID, excludeReviewID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []string{}
+	for rows.Next() {
+		var comment string
+		if err := rows.Scan(&comment); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// receivedReviewsQuery は受信レビューを新しい順に返す（GetReceivedReviews）
+const receivedReviewsQuery = `
+	SELECT
+	  r.id AS review_id,
+	  COALESCE(r.match_id::text, ''),
+	  COALESCE(u.id::text, '') AS user_id,
+	  COALESCE(u.username, ''),
+	  u.icon_path,
+	  COALESCE(w.id::text, '') AS work_id,
+	  w.image_path AS work_image_path,
+	  COALESCE(w.title, ''),
+	  CASE WHEN l.unlocked THEN r.comment ELSE '' END,
+	  CASE WHEN l.unlocked THEN r.sections ELSE '[]'::jsonb END,
+	  NOT l.unlocked AS is_locked,
+	  r.edited_at IS NOT NULL AS edited,
+	  r.anonymized_at IS NOT NULL AS is_anonymous,
+	  r.created_at
+	FROM public.reviews r
+	CROSS JOIN LATERAL (
+	  SELECT ` + ReviewUnlockedCondition + ` AS unlocked
+	) l
+	LEFT JOIN public.users u
+	  ON u.id = r.from_user_id
+	LEFT JOIN public.works w
+	  ON w.id = r.work_id
+	WHERE r.to_user_id = $1
+	  AND ($2 OR l.unlocked)
+	  AND r.hidden_at IS NULL
+	  AND u.suspended_at IS NULL
+	  AND u.deleted_at IS NULL
+	ORDER BY r.created_at DESC
+`
+
+func (r *PGReviews) Received(ctx context.Context, userID string, includeLocked bool) ([]ReceivedReview, error) {
+	rows, err := r.pool.Query(ctx, receivedReviewsQuery, userID, includeLocked)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []ReceivedReview
+	for rows.Next() {
+		var rv ReceivedReview
+		if err := rows.Scan(
+			&rv.ID,
+			&rv.MatchID,
+			&rv.FromUserID,
+			&rv.FromUsername,
+			&rv.FromIconPath,
+			&rv.WorkID,
+			&rv.WorkImagePath,
+			&rv.WorkTitle,
+			&rv.Comment,
+			&rv.Sections,
+			&rv.Locked,
+			&rv.Edited,
+			&rv.Anonymous,
+			&rv.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, rv)
+	}
+	return reviews, rows.Err()
+}
+
+func (r *PGReviews) Statuses(ctx context.Context, userID string) ([]ReviewStatus, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  m.id,
+		  u.id,
+		  u.username,
+		  u.icon_path,
+		  mine.id IS NOT NULL AS has_sent_review,
+		  theirs.id AS received_review_id
+		FROM public.matches m
+		JOIN public.users u
+		  ON u.id = CASE
+		    WHEN m.user1_id = $1 THEN m.user2_id
+		    ELSE m.user1_id
+		  END
+		LEFT JOIN public.reviews mine
+		  ON mine.match_id = m.id
+		 AND mine.from_user_id = $1
+		LEFT JOIN public.reviews theirs
+		  ON theirs.match_id = m.id
+		 AND theirs.to_user_id = $1
+		 AND theirs.hidden_at IS NULL
+		WHERE $1 IN (m.user1_id, m.user2_id)
+		ORDER BY m.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []ReviewStatus
+	for rows.Next() {
+		var s ReviewStatus
+		if err := rows.Scan(
+			&s.MatchID,
+			&s.UserID,
+			&s.Username,
+			&s.IconPath,
+			&s.HasSentReview,
+			&s.ReceivedReviewID,
+		); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, rows.Err()
+}
+
+func (r *PGReviews) Edit(ctx context.Context, id string, check func(Review) error, e ReviewEdit) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	old, err := scanReview(tx.QueryRow(ctx, getReviewQuery+` FOR UPDATE OF r`, id))
+	if err != nil {
+		return err
+	}
+	if err := check(old); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO public.review_revisions (review_id, comment, sections)
+		VALUES ($1, $2, $3)
+	`, id, old.Comment, old.Sections); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE public.reviews
+		SET comment = $2,
+		    sections = $3,
+		    quality_flags = $4,
+		    edited_at = now()
+		WHERE id = $1
+	`, id, e.Comment, e.Sections, e.QualityFlags); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PGReviews) Revisions(ctx context.Context, id string) ([]ReviewRevision, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT comment, sections, created_at
+		FROM public.review_revisions
+		WHERE review_id = $1
+		ORDER BY created_at
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []ReviewRevision
+	for rows.Next() {
+		var rev ReviewRevision
+		if err := rows.Scan(&rev.Comment, &rev.Sections, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *PGReviews) MarkRead(ctx context.Context, id string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.reviews
+		SET read_at = now()
+		WHERE id = $1
+		  AND read_at IS NULL
+	`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PGReviews) React(ctx context.Context, id, reaction string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO public.review_reactions (review_id, reaction)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, id, reaction)
+	if err != nil {
+		return false, pgError(err)
+	}
+	if _, err := r.MarkRead(ctx, id); err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PGReviews) Reactions(ctx context.Context, id string) ([]string, error) {
+	reactions := []string{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(array_agg(reaction ORDER BY created_at), '{}')
+		FROM public.review_reactions
+		WHERE review_id = $1
+	`, id).Scan(&reactions)
+	return reactions, err
+}
+
+func (r *PGReviews) Sent(ctx context.Context, userID string, after *Cursor, ascending bool, limit int) ([]SentReview, error) {
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+	afterAt, afterID := cursorArgs(after)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  r.id,
+		  COALESCE(r.match_id::text, ''),
+		  u.id,
+		  u.username,
+		  u.icon_path,
+		  COALESCE(w.id::text, ''),
+		  COALESCE(w.title, ''),
+		  w.image_path,
+		  r.comment,
+		  r.sections,
+		  `+ReviewUnlockedCondition+` AS reviewed_back,
+		  COALESCE((
+		    SELECT array_agg(rr.reaction ORDER BY rr.created_at)
+		    FROM public.review_reactions rr
+		    WHERE rr.review_id = r.id
+		  ), '{}'),
+		  r.read_at,
+		  r.edited_at,
+		  r.created_at
+		FROM public.reviews r
+		JOIN public.users u
+		  ON u.id = r.to_user_id
+		LEFT JOIN public.works w
+		  ON w.id = r.work_id
+		WHERE r.from_user_id = $1
+		  AND (
+		    $2::timestamptz IS NULL
+		    OR ($4 AND (r.created_at, r.id) > ($2, $3::uuid))
+		    OR (NOT $4 AND (r.created_at, r.id) < ($2, $3::uuid))
+		  )
+		ORDER BY r.created_at `+order+`, r.id `+order+`
+		LIMIT $5
+	`, userID, afterAt, afterID, ascending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []SentReview
+	for rows.Next() {
+		var rv SentReview
+		if err := rows.Scan(
+			&rv.ID,
+			&rv.MatchID,
+			&rv.ToUserID,
+			&rv.ToUsername,
+			&rv.ToIconPath,
+			&rv.WorkID,
+			&rv.WorkTitle,
+			&rv.WorkImagePath,
+			&rv.Comment,
+			&rv.Sections,
+			&rv.ReviewedBack,
+			&rv.Reactions,
+			&rv.ReadAt,
+			&rv.EditedAt,
+			&rv.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, rv)
+	}
+	return reviews, rows.Err()
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGStats は swipes / matches / reviews を集計する Stats 実装
+type PGStats struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGStats(pool *pgxpool.Pool) *PGStats {
+	return &PGStats{pool: pool}
+}
+
+func (r *PGStats) Summary(ctx context.Context, userID string) (StatsSummary, error) {
+	var s StatsSummary
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+		  (SELECT COUNT(*) FROM public.matches m WHERE $1 IN (m.user1_id, m.user2_id)),
+		  (SELECT COUNT(*) FROM public.reviews r WHERE r.from_user_id = $1),
+		  (SELECT COUNT(*) FROM public.reviews r WHERE r.to_user_id = $1),
+		  (
+		    SELECT AVG(EXTRACT(EPOCH FROM mine.created_at - theirs.created_at))::float8
+		    FROM public.reviews mine
+		    JOIN public.reviews theirs
+		      ON theirs.match_id = mine.match_id
+		     AND theirs.from_user_id = mine.to_user_id
+		    WHERE mine.from_user_id = $1
+		      AND mine.created_at > theirs.created_at
+		  )
+	`, userID).Scan(&s.Matches, &s.ReviewsSent, &s.ReviewsReceived, &s.AvgResponseSeconds)
+	return s, err
+}
+
+func (r *PGStats) WorkSwipes(ctx context.Context, userID string) ([]WorkSwipes, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+		  w.id,
+		  w.title,
+		  COUNT(sw.id) FILTER (WHERE sw.is_like),
+		  COUNT(sw.id) FILTER (WHERE NOT sw.is_like)
+		FROM public.works w
+		LEFT JOIN public.swipes sw
+		  ON sw.to_work_id = w.id
+		WHERE w.user_id = $1
+		GROUP BY w.id
+		ORDER BY w.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []WorkSwipes
+	for rows.Next() {
+		var w WorkSwipes
+		if err := rows.Scan(&w.WorkID, &w.Title, &w.Likes, &w.Passes); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+	return works, rows.Err()
+}
+
+func (r *PGStats) Daily(ctx context.Context, userID string, days int) ([]DailyCounts, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH days AS (
+		  SELECT generate_series(
+		    date_trunc('day', now() AT TIME ZONE 'UTC') - make_interval(days => $2 - 1),
+		    date_trunc('day', now() AT TIME ZONE 'UTC'),
+		    interval '1 day'
+		  ) AT TIME ZONE 'UTC' AS day
+		)
+		SELECT
+		  d.day,
+		  (SELECT COUNT(*) FROM public.swipes sw
+		    WHERE sw.to_work_user_id = $1 AND sw.is_like
+		      AND sw.created_at >= d.day AND sw.created_at < d.day + interval '1 day'),
+		  (SELECT COUNT(*) FROM public.swipes sw
+		    WHERE sw.to_work_user_id = $1 AND NOT sw.is_like
+		      AND sw.created_at >= d.day AND sw.created_at < d.day + interval '1 day'),
+		  (SELECT COUNT(*) FROM public.matches m
+		    WHERE $1 IN (m.user1_id, m.user2_id)
+		      AND m.created_at >= d.day AND m.created_at < d.day + interval '1 day'),
+		  (SELECT COUNT(*) FROM public.reviews r
+		    WHERE r.from_user_id = $1
+		      AND r.created_at >= d.day AND r.created_at < d.day + interval '1 day'),
+		  (SELECT COUNT(*) FROM public.reviews r
+		    WHERE r.to_user_id = $1
+		      AND r.created_at >= d.day AND r.created_at < d.day + interval '1 day')
+		FROM days d
+		ORDER BY d.day
+	`, userID, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var daily []DailyCounts
+	for rows.Next() {
+		var d DailyCounts
+		if err := rows.Scan(&d.Day, &d.Likes, &d.Passes, &d.Matches, &d.ReviewsSent, &d.ReviewsReceived); err != nil {
+			return nil, err
+		}
+		daily = append(daily, d)
+	}
+	return daily, rows.Err()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGSwipes は swipes テーブルを使う Swipes 実装
+type PGSwipes struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGSwipes(pool *pgxpool.Pool) *PGSwipes {
+	return &PGSwipes{pool: pool}
+}
+
+func (r *PGSwipes) Save(ctx context.Context, fromUserID, toWorkID string, isLike bool) error {
+	res, err := r.pool.Exec(ctx, `
+		INSERT INTO public.swipes (from_user_id, to_work_id, to_work_user_id, is_like)
+		SELECT $1, w.id, w.user_id, $2
+		FROM public.works w
+		WHERE w.id = $3 AND w.user_id <> $1
+		ON CONFLICT (from_user_id, to_work_id) DO UPDATE
+		SET is_like = EXCLUDED.is_like, created_at = now()
+	`, fromUserID, isLike, toWorkID)
+	if err != nil {
+		return pgError(err)
+	}
+	// 作品がない、または自分の作品
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// findLikeQuery は相手の作品へのいいねを探す（CheckAndCreateMatch）
+const findLikeQuery = `
+	SELECT to_work_id
+	FROM swipes
+	WHERE from_user_id=$1
+	  AND to_work_user_id=$2
+	  AND is_like=true
+`
+
+func (r *PGSwipes) FindLike(ctx context.Context, fromUserID, toUserID string) (string, error) {
+	var workID string
+	err := r.pool.QueryRow(ctx, findLikeQuery, fromUserID, toUserID).Scan(&workID)
+	return workID, pgError(err)
+}
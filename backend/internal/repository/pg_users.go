@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGUsers は users テーブルを使う Users 実装
+type PGUsers struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGUsers(pool *pgxpool.Pool) *PGUsers {
+	return &PGUsers{pool: pool}
+}
+
+func (r *PGUsers) Create(ctx context.Context, u User) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx,
+		"INSERT INTO public.users (username, email, password) VALUES ($1, $2, $3) RETURNING id",
+		u.Username, strings.ToLower(u.Email), u.Password,
+	).Scan(&id)
+	return id, pgError(err)
+}
+
+func (r *PGUsers) EmailExists(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM public.users WHERE LOWER(email)=$1)",
+		strings.ToLower(email),
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *PGUsers) FindByEmail(ctx context.Context, email string) (User, error) {
+	return r.findOne(ctx, "LOWER(email) = $1", strings.ToLower(email))
+}
+
+func (r *PGUsers) Get(ctx context.Context, id string) (User, error) {
+	return r.findOne(ctx, "id = $1", id)
+}
+
+const userColumns = `id, username, handle, email, password, icon_path, bio, is_admin, suspended_at, deleted_at`
+
+func scanUser(row pgx.Row) (User, error) {
+	var u User
+	err := row.Scan(&u.ID, &u.Username, &u.Handle, &u.Email, &u.Password, &u.IconPath, &u.Bio, &u.IsAdmin, &u.SuspendedAt, &u.DeletedAt)
+	return u, err
+}
+
+func (r *PGUsers) findOne(ctx context.Context, where string, arg any) (User, error) {
+	u, err := scanUser(r.pool.QueryRow(ctx, `
+		SELECT `+userColumns+`
+		FROM public.users
+		WHERE `+where, arg,
+	))
+	return u, pgError(err)
+}
+
+func (r *PGUsers) List(ctx context.Context) ([]User, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+userColumns+` FROM public.users`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (r *PGUsers) UpdateProfile(ctx context.Context, id string, p ProfileUpdate) error {
+	sets := []string{}
+	params := []any{id}
+	if p.Username != nil {
+		params = append(params, *p.Username)
+		sets = append(sets, "username = $"+strconv.Itoa(len(params)))
+	}
+	switch {
+	case p.ResetBio:
+		sets = append(sets, "bio = NULL")
+	case p.Bio != nil:
+		params = append(params, *p.Bio)
+		sets = append(sets, "bio = $"+strconv.Itoa(len(params)))
+	}
+	if len(sets) == 0 {
+		// 変える項目がなくても、ユーザーがいるかは確かめる
+		_, err := r.Get(ctx, id)
+		return err
+	}
+
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE public.users SET `+strings.Join(sets, ", ")+` WHERE id = $1`,
+		params...,
+	)
+	if err != nil {
+		return pgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGUsers) SetIconPath(ctx context.Context, id string, iconPath *string) (*string, error) {
+	var oldPath *string
+	err := r.pool.QueryRow(ctx, `
+		UPDATE public.users u
+		SET icon_path = $2
+		FROM (
+		  SELECT icon_path
+		  FROM public.users
+		  WHERE id = $1
+		  FOR UPDATE
+		) old
+		WHERE u.id = $1
+		RETURNING old.icon_path
+	`, id, iconPath).Scan(&oldPath)
+	return oldPath, pgError(err)
+}
+
+func (r *PGUsers) SetAdmin(ctx context.Context, id string, isAdmin bool) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE public.users SET is_admin = $2 WHERE id = $1`, id, isAdmin)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *PGUsers) Suspend(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE public.users
+		SET suspended_at = COALESCE(suspended_at, now())
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
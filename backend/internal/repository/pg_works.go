@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGWorks は works テーブルを使う Works 実装
+type PGWorks struct {
+	pool *pgxpool.Pool
+}
+
+func NewPGWorks(pool *pgxpool.Pool) *PGWorks {
+	return &PGWorks{pool: pool}
+}
+
+func (r *PGWorks) Save(ctx context.Context, w Work) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO works (user_id, image_path, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, image_path)
+		DO UPDATE SET title = EXCLUDED.title,
+		              description = EXCLUDED.description
+		RETURNING id
+	`, w.UserID, w.ImagePath, w.Title, w.Description).Scan(&id)
+	return id, pgError(err)
+}
+
+// listWorksByUserQuery はユーザーの作品を新しい順に返す
+const listWorksByUserQuery = `
+	SELECT id, user_id, image_path, title, description, created_at
+	FROM public.works
+	WHERE user_id = $1
+	ORDER BY created_at DESC
+`
+
+func (r *PGWorks) ListByUser(ctx context.Context, userID string) ([]Work, error) {
+	rows, err := r.pool.Query(ctx, listWorksByUserQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []Work
+	for rows.Next() {
+		var w Work
+		if err := rows.Scan(&w.ID, &w.UserID, &w.ImagePath, &w.Title, &w.Description, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+	return works, rows.Err()
+}
+
+// unswipedWorkIDsQuery はまだスワイプしていない他人の作品を返す（GetWorks）
+const unswipedWorkIDsQuery = `
+	SELECT w.id
+	FROM public.works w
+	JOIN public.users u
+	    ON u.id = w.user_id
+	LEFT JOIN public.swipes s
+	    ON s.from_user_id = $1 AND s.to_work_id = w.id
+	WHERE w.user_id <> $1
+	    AND s.id IS NULL
+	    AND w.hidden_at IS NULL
+	    AND u.suspended_at IS NULL
+	    AND u.deleted_at IS NULL
+`
+
+func (r *PGWorks) UnswipedIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, unswipedWorkIDsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *PGWorks) Feed(ctx context.Context, ids []string) ([]FeedWork, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT w.id, w.user_id, u.username, u.icon_path, w.image_path, w.title, w.description, w.created_at
+		FROM public.works w
+		JOIN public.users u ON u.id = w.user_id
+		WHERE w.id = ANY($1)
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []FeedWork
+	for rows.Next() {
+		var w FeedWork
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Username, &w.IconPath, &w.ImagePath, &w.Title, &w.Description, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+	return works, rows.Err()
+}
+
+func (r *PGWorks) Owner(ctx context.Context, workID string) (string, error) {
+	var userID string
+	err := r.pool.QueryRow(ctx, `SELECT user_id FROM works WHERE id=$1`, workID).Scan(&userID)
+	return userID, pgError(err)
+}
+
+func (r *PGWorks) ListAll(ctx context.Context) ([]FeedWork, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT w.id, w.user_id, u.username, u.icon_path, w.image_path, w.title, w.description, w.hidden_at, w.created_at
+		FROM public.works w
+		JOIN public.users u ON u.id = w.user_id
+		ORDER BY w.created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []FeedWork
+	for rows.Next() {
+		var w FeedWork
+		if err := rows.Scan(&w.ID, &w.UserID, &w.Username, &w.IconPath, &w.ImagePath, &w.Title, &w.Description, &w.HiddenAt, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+	return works, rows.Err()
+}
+
+func (r *PGWorks) CountVisible(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM public.works
+		WHERE user_id = $1
+		  AND hidden_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+func (r *PGWorks) PageVisible(ctx context.Context, userID string, after *Cursor, limit int) ([]Work, error) {
+	afterAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id, image_path, title, description, created_at
+		FROM public.works
+		WHERE user_id = $1
+		  AND hidden_at IS NULL
+		  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`, userID, afterAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []Work
+	for rows.Next() {
+		var w Work
+		if err := rows.Scan(&w.ID, &w.UserID, &w.ImagePath, &w.Title, &w.Description, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+	return works, rows.Err()
+}
@@ -0,0 +1,546 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/guideline"
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+)
+
+var (
+	// ErrNotFound は対象の行がない
+	ErrNotFound = errors.New("repository: not found")
+	// ErrConflict は一意制約に当たった（同じものが既にある）
+	ErrConflict = errors.New("repository: already exists")
+	// ErrMissingReference は参照先（ユーザーなど）がない
+	ErrMissingReference = errors.New("repository: missing reference")
+)
+
+// Repositories はハンドラとサービスが使うリポジトリ一式
+type Repositories struct {
+	Users         Users
+	Works         Works
+	Swipes        Swipes
+	Matches       Matches
+	Reviews       Reviews
+	Accounts      Accounts
+	Handles       Handles
+	Blocks        Blocks
+	Devices       Devices
+	Notifications Notifications
+	Messages      Messages
+	Reports       Reports
+	Stats         Stats
+}
+
+// Cursor は (created_at, id) のキーセットページングの位置
+// created_at が同じ行も id で順序が決まるので、ページの境目で抜けや重複が出ない
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+type User struct {
+	ID          string
+	Username    string
+	Handle      *string
+	Email       string
+	Password    string
+	IconPath    *string
+	Bio         *string
+	IsAdmin     bool
+	SuspendedAt *time.Time // 停止中なら停止日時
+	DeletedAt   *time.Time // 退会申請中なら申請日時
+}
+
+// ProfileUpdate はプロフィールの部分更新（nil の項目は変えない）
+type ProfileUpdate struct {
+	Username *string
+	Bio      *string
+	ResetBio bool // bio を null（既定の自己紹介）に戻す
+}
+
+type Users interface {
+	// Create はユーザーを登録して ID を返す（email は小文字で保存する）
+	// 同じ email があれば ErrConflict
+	Create(ctx context.Context, u User) (string, error)
+	EmailExists(ctx context.Context, email string) (bool, error)
+	// FindByEmail は大文字小文字を区別せずに email で探す
+	FindByEmail(ctx context.Context, email string) (User, error)
+	Get(ctx context.Context, id string) (User, error)
+	// List は全ユーザーを返す（デバッグ用）
+	List(ctx context.Context) ([]User, error)
+	// UpdateProfile は表示名と自己紹介を更新する。ユーザーがいなければ ErrNotFound
+	UpdateProfile(ctx context.Context, id string, p ProfileUpdate) error
+	// SetIconPath はアイコンを差し替えて以前の icon_path を返す（nil は既定のアイコン）
+	SetIconPath(ctx context.Context, id string, iconPath *string) (*string, error)
+	// SetAdmin は管理者の権限を付け外しする
+	SetAdmin(ctx context.Context, id string, isAdmin bool) error
+	// Suspend はユーザーを停止する（停止済みならそのまま）
+	Suspend(ctx context.Context, id string) error
+}
+
+type Work struct {
+	ID          string
+	UserID      string
+	Title       string
+	Description *string
+	ImagePath   *string
+	HiddenAt    *time.Time // モデレーションで非表示にした日時
+	CreatedAt   time.Time
+}
+
+// FeedWork はホーム画面に出す作品（作者の表示名とアイコン付き）
+type FeedWork struct {
+	Work
+	Username string
+	IconPath *string
+}
+
+type Works interface {
+	// Save は作品を保存して ID を返す
+	// 同じユーザーの同じ画像があればタイトルと説明を上書きする
+	Save(ctx context.Context, w Work) (string, error)
+	// ListByUser はユーザーの作品を新しい順に返す
+	ListByUser(ctx context.Context, userID string) ([]Work, error)
+	// UnswipedIDs は userID がまだスワイプしていない他人の作品 ID を返す
+	// 非表示の作品と、停止中・退会申請中のユーザーの作品は含めない
+	UnswipedIDs(ctx context.Context, userID string) ([]string, error)
+	// Feed は ids の作品を作者情報付きで返す（順序は不定）
+	Feed(ctx context.Context, ids []string) ([]FeedWork, error)
+	// Owner は作品の作者 ID を返す
+	Owner(ctx context.Context, workID string) (string, error)
+	// ListAll は全作品を作者名付きで新しい順に返す（デバッグ用）
+	ListAll(ctx context.Context) ([]FeedWork, error)
+	// CountVisible は userID の非表示でない作品の数を返す
+	CountVisible(ctx context.Context, userID string) (int, error)
+	// PageVisible は userID の非表示でない作品を新しい順に、after の続きから limit 件返す
+	PageVisible(ctx context.Context, userID string, after *Cursor, limit int) ([]Work, error)
+}
+
+type Swipes interface {
+	// Save はスワイプを保存する（同じ作品へのやり直しは上書き）
+	// 作品がない・自分の作品なら ErrNotFound、スワイプしたユーザーがいなければ ErrMissingReference
+	Save(ctx context.Context, fromUserID, toWorkID string, isLike bool) error
+	// FindLike は fromUserID が toUserID の作品にいいねしていれば、その作品 ID を返す
+	FindLike(ctx context.Context, fromUserID, toUserID string) (string, error)
+}
+
+// Match は user1_id < user2_id に並べて保存する
+type Match struct {
+	ID        string
+	User1ID   string
+	User2ID   string
+	Work1ID   string
+	Work2ID   string
+	CreatedAt time.Time
+}
+
+// Partner は userID から見た相手と相手の作品を返す（参加者でなければ ok=false）
+func (m Match) Partner(userID string) (partnerID, partnerWorkID string, ok bool) {
+	switch userID {
+	case m.User1ID:
+		return m.User2ID, m.Work2ID, true
+	case m.User2ID:
+		return m.User1ID, m.Work1ID, true
+	}
+	return "", "", false
+}
+
+// ordered は user_id の順序を固定する（作品も一緒に入れ替える）
+func (m Match) ordered() Match {
+	if m.User2ID < m.User1ID {
+		m.User1ID, m.User2ID = m.User2ID, m.User1ID
+		m.Work1ID, m.Work2ID = m.Work2ID, m.Work1ID
+	}
+	return m
+}
+
+// MatchSummary はマッチ一覧の 1 件（相手と相手の作品）
+type MatchSummary struct {
+	MatchID       string
+	UserID        string
+	Username      string
+	IconPath      *string
+	WorkImagePath *string
+	WorkTitle     string
+	IsReviewed    bool // 自分が相手にレビューを送ったか
+}
+
+type Matches interface {
+	// Create はマッチを保存して返す（ユーザーの順序はここで揃える）
+	// 同じ 2 人のマッチが既にあれば ErrConflict
+	Create(ctx context.Context, m Match) (Match, error)
+	Get(ctx context.Context, id string) (Match, error)
+	// ListForUser は userID のマッチを新しい順に返す
+	// 停止中・退会申請中の相手や、相手の作品が非表示になったマッチは含めない
+	ListForUser(ctx context.Context, userID string) ([]MatchSummary, error)
+}
+
+// Review は送ったレビュー
+// 退会したユーザーの匿名化されたレビューは FromUserID と MatchID、WorkID が空
+type Review struct {
+	ID           string
+	MatchID      string
+	FromUserID   string
+	ToUserID     string
+	WorkID       string
+	Comment      string
+	Sections     []guideline.Section
+	QualityFlags []string
+	Unlocked     bool // 受信者が読めるか（Get のときだけ入る）
+	CreatedAt    time.Time
+}
+
+// ReviewEdit はレビューの編集内容
+type ReviewEdit struct {
+	Comment      string
+	Sections     []guideline.Section
+	QualityFlags []string
+}
+
+// ReviewRevision は編集前のレビュー本文
+type ReviewRevision struct {
+	Comment   string
+	Sections  []guideline.Section
+	CreatedAt time.Time
+}
+
+// SentReview は送ったレビューの 1 件（相手と作品、既読・リアクションの状況付き）
+// 作品が削除されていれば WorkID と WorkTitle は空
+type SentReview struct {
+	ID            string
+	MatchID       string
+	ToUserID      string
+	ToUsername    string
+	ToIconPath    *string
+	WorkID        string
+	WorkTitle     string
+	WorkImagePath *string
+	Comment       string
+	Sections      []guideline.Section
+	ReviewedBack  bool // 相手も自分にレビューを送ったか
+	Reactions     []string
+	ReadAt        *time.Time
+	EditedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+// ReceivedReview は受信したレビューの 1 件
+// ロック中（お返しのレビューを送っていない）なら本文は空
+// 退会したユーザーの匿名化されたレビューは FromUserID と MatchID、WorkID が空
+type ReceivedReview struct {
+	ID            string
+	MatchID       string
+	FromUserID    string
+	FromUsername  string
+	FromIconPath  *string
+	WorkID        string
+	WorkImagePath *string
+	WorkTitle     string
+	Comment       string
+	Sections      []guideline.Section
+	Locked        bool
+	Edited        bool
+	Anonymous     bool
+	CreatedAt     time.Time
+}
+
+// ReviewStatus はマッチごとのレビューの送受信状況
+type ReviewStatus struct {
+	MatchID          string
+	UserID           string
+	Username         string
+	IconPath         *string
+	HasSentReview    bool
+	ReceivedReviewID *string
+}
+
+type Reviews interface {
+	// Create はレビューを保存して ID を返す
+	// 同じマッチで既に送っていれば ErrConflict
+	Create(ctx context.Context, r Review) (string, error)
+	Get(ctx context.Context, id string) (Review, error)
+	// FindID は matchID で fromUserID が送ったレビューの ID を返す
+	FindID(ctx context.Context, matchID, fromUserID string) (string, error)
+	// RecentComments はユーザーが最近送ったレビューの本文を新しい順に limit 件返す
+	// excludeReviewID には編集中のレビューを渡す（空なら除外しない）
+	RecentComments(ctx context.Context, userID, excludeReviewID string, limit int) ([]string, error)
+	// Received は userID が受け取ったレビューを新しい順に返す
+	// includeLocked ならロック中のものも本文なしで含める
+	Received(ctx context.Context, userID string, includeLocked bool) ([]ReceivedReview, error)
+	// Statuses は userID のマッチごとの送受信状況を新しい順に返す
+	// 非表示になった受信レビューは受け取っていない扱いにする（Received と揃える）
+	Statuses(ctx context.Context, userID string) ([]ReviewStatus, error)
+	// Edit はレビューを書き換え、編集前の本文を履歴に残す
+	// check には編集前のレビューを行をロックしたまま渡し、エラーを返せば編集しない
+	Edit(ctx context.Context, id string, check func(Review) error, e ReviewEdit) error
+	// Revisions は編集前の本文を古い順に返す
+	Revisions(ctx context.Context, id string) ([]ReviewRevision, error)
+	// MarkRead は最初に読んだ日時を残す。今回既読にしたら true
+	MarkRead(ctx context.Context, id string) (bool, error)
+	// React はリアクションを付けて既読にする。今回付けたら true（同じ種類は 1 回まで）
+	React(ctx context.Context, id, reaction string) (bool, error)
+	// Reactions は付いたリアクションを古い順に返す
+	Reactions(ctx context.Context, id string) ([]string, error)
+	// Sent は userID が送ったレビューを after の続きから limit 件返す（ascending なら古い順）
+	Sent(ctx context.Context, userID string, after *Cursor, ascending bool, limit int) ([]SentReview, error)
+}
+
+// Export は本人のデータのエクスポート（JSON の項目名は列名に合わせる）
+type Export struct {
+	Profile         ExportProfile
+	Works           []ExportWork
+	Swipes          []ExportSwipe
+	Matches         []ExportMatch
+	ReviewsSent     []ExportSentReview
+	ReviewsReceived []ExportReceivedReview // 読めるものだけ
+}
+
+type ExportProfile struct {
+	ID        string     `json:"id"`
+	Username  string     `json:"username"`
+	Handle    *string    `json:"handle"`
+	Email     string     `json:"email"`
+	Bio       *string    `json:"bio"`
+	IconPath  *string    `json:"icon_path"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type ExportWork struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	ImagePath   *string   `json:"image_path"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ExportSwipe struct {
+	ID        string    `json:"id"`
+	ToWorkID  string    `json:"to_work_id"`
+	IsLike    bool      `json:"is_like"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ExportMatch struct {
+	ID            string    `json:"id"`
+	PartnerID     string    `json:"partner_id"`
+	MyWorkID      string    `json:"my_work_id"`
+	PartnerWorkID string    `json:"partner_work_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ExportSentReview struct {
+	ID        string              `json:"id"`
+	MatchID   *string             `json:"match_id"`
+	ToUserID  string              `json:"to_user_id"`
+	WorkID    *string             `json:"work_id"`
+	Comment   string              `json:"comment"`
+	Sections  []guideline.Section `json:"sections"`
+	EditedAt  *time.Time          `json:"edited_at"`
+	ReadAt    *time.Time          `json:"read_at"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type ExportReceivedReview struct {
+	ID          string              `json:"id"`
+	MatchID     *string             `json:"match_id"`
+	FromUserID  *string             `json:"from_user_id"`
+	WorkID      *string             `json:"work_id"`
+	Comment     string              `json:"comment"`
+	Sections    []guideline.Section `json:"sections"`
+	CreatedAt   time.Time           `json:"created_at"`
+	MyReactions []string            `json:"my_reactions"`
+}
+
+// Accounts は退会とデータのエクスポート
+type Accounts interface {
+	// ScheduleDeletion は退会を申請して申請日時を返す（申請済みなら最初の日時）
+	ScheduleDeletion(ctx context.Context, userID string) (time.Time, error)
+	// CancelDeletion は退会申請を取り消す。申請していなければ ErrNotFound
+	CancelDeletion(ctx context.Context, userID string) error
+	// DueForPurge は before 以前に退会を申請したユーザーの ID を返す
+	DueForPurge(ctx context.Context, before time.Time) ([]string, error)
+	// Purge は退会申請中のユーザーを削除し、ストレージから消す画像（作品とアイコン）のパスを返す
+	// 他の人に書いたレビューは匿名化して残し、編集履歴は消す。申請中でなければ ErrNotFound
+	Purge(ctx context.Context, userID string) ([]string, error)
+	// Export は本人のデータを集める。ユーザーがいなければ ErrNotFound
+	Export(ctx context.Context, userID string) (Export, error)
+}
+
+type Handles interface {
+	// Change は userID のハンドルを handle に変え、以前のハンドルを履歴に残す
+	// 今と同じハンドルなら変えずに false を返す
+	// check には今のハンドルと最後に変えた日時を渡し、エラーを返せば変えない
+	// 使用中のハンドルや、他の人が heldSince より後に手放したハンドルなら ErrConflict
+	Change(ctx context.Context, userID, handle string, heldSince time.Time, check func(current *string, changedAt *time.Time) error) (bool, error)
+	// Find はハンドルを使っている有効なユーザー（停止中・退会申請中でない）の ID を返す
+	Find(ctx context.Context, handle string) (string, error)
+	// FindPrevious は since より後に手放されたハンドルについて、持ち主の今のハンドルを返す
+	FindPrevious(ctx context.Context, handle string, since time.Time) (string, error)
+}
+
+// BlockedUser はブロックしている相手
+type BlockedUser struct {
+	UserID    string
+	Username  string
+	IconPath  *string
+	CreatedAt time.Time
+}
+
+type Blocks interface {
+	// Block は blockerID が blockedID をブロックする（ブロック済みならそのまま）
+	// どちらかのユーザーがいなければ ErrMissingReference
+	Block(ctx context.Context, blockerID, blockedID string) error
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	// List は blockerID がブロックしている相手を新しい順に返す
+	List(ctx context.Context, blockerID string) ([]BlockedUser, error)
+	// IsBlocked は 2 人のどちらかが相手をブロックしているか
+	IsBlocked(ctx context.Context, userA, userB string) (bool, error)
+}
+
+// Devices はプッシュ通知用の端末トークン（notify.Dispatcher の TokenStore にもなる）
+type Devices interface {
+	notify.TokenStore
+	// Register は端末を登録する（同じトークンが別のユーザーにあれば付け替える）
+	// ユーザーがいなければ ErrMissingReference
+	Register(ctx context.Context, userID string, d notify.Device) error
+	// Unregister は userID の端末トークンを消す
+	Unregister(ctx context.Context, userID, token string) error
+	// RemoveForUser は userID の端末トークンをすべて消す
+	RemoveForUser(ctx context.Context, userID string) error
+}
+
+// Notification は受信箱に追加する通知（関連しない ID は空）
+type Notification struct {
+	UserID      string
+	Type        string
+	ActorUserID string
+	MatchID     string
+	ReviewID    string
+}
+
+// InboxNotification は受信箱の 1 件（相手の表示名とアイコン付き）
+type InboxNotification struct {
+	ID            string
+	Type          string
+	ActorUserID   *string
+	ActorUsername *string
+	ActorIconPath *string
+	MatchID       *string
+	ReviewID      *string
+	IsRead        bool
+	CreatedAt     time.Time
+}
+
+type Notifications interface {
+	// Create は通知を受信箱に追加する。その種別をオフにしていれば追加せずに false
+	Create(ctx context.Context, n Notification) (bool, error)
+	// RemindUnreviewed は from より後、to 以前に成立したマッチでまだレビューを送っていない人に
+	// typ の通知を作り、作った通知を返す（同じマッチへは 1 回まで、オフにしている人には作らない）
+	RemindUnreviewed(ctx context.Context, typ string, from, to time.Time) ([]Notification, error)
+	// List は userID の通知を新しい順に、after の続きから limit 件返す
+	List(ctx context.Context, userID string, after *Cursor, unreadOnly bool, limit int) ([]InboxNotification, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	// MarkRead は ids（all なら全件）の未読の通知を既読にし、その件数を返す
+	MarkRead(ctx context.Context, userID string, ids []string, all bool) (int64, error)
+	// Preferences は保存してある通知設定（種別ごとのオン・オフ）を返す
+	Preferences(ctx context.Context, userID string) (map[string]bool, error)
+	// SetPreference は種別の通知設定を保存する。ユーザーがいなければ ErrMissingReference
+	SetPreference(ctx context.Context, userID, typ string, enabled bool) error
+}
+
+type Message struct {
+	ID        string
+	MatchID   string
+	SenderID  string
+	Body      string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+type Messages interface {
+	// List は matchID のメッセージを新しい順に、after の続きから limit 件返す
+	List(ctx context.Context, matchID string, after *Cursor, limit int) ([]Message, error)
+	// Create はメッセージを保存して返す
+	Create(ctx context.Context, m Message) (Message, error)
+	// MarkRead は matchID で senderID が送った未読のメッセージを既読にし、その件数を返す
+	MarkRead(ctx context.Context, matchID, senderID string) (int64, error)
+}
+
+// Report は通報（ReporterID が nil ならシステムによる自動通報）
+type Report struct {
+	ID             string
+	ReporterID     *string
+	TargetType     string
+	TargetID       string
+	Reason         string
+	Note           string
+	Status         string
+	Action         *string
+	HandledBy      *string
+	ResolutionNote string
+	ReportCount    int // 同じ対象への未処理の通報数（List のときだけ入る）
+	CreatedAt      time.Time
+}
+
+// Moderation は通報を閉じるときの対応
+type Moderation struct {
+	Status       string
+	Action       string
+	HideTarget   bool // 対象のレビュー・作品を非表示にする
+	SuspendOwner bool // 対象の持ち主を停止する
+}
+
+type Reports interface {
+	// TargetOwner は通報対象（review / work / user）の持ち主の ID を返す
+	// 対象がないときや、持ち主が退会して消えたときは ErrNotFound
+	TargetOwner(ctx context.Context, targetType, targetID string) (string, error)
+	// Create は通報を保存して ID を返す
+	// 同じ人が同じ対象を通報していて、まだ処理していなければ ErrConflict
+	Create(ctx context.Context, r Report) (string, error)
+	// List は statuses の通報を古い順に limit 件返す
+	List(ctx context.Context, statuses []string, limit int) ([]Report, error)
+	// Triage は未処理の通報を確認中にして担当者を記録する。未処理の通報がなければ ErrNotFound
+	Triage(ctx context.Context, id, adminID string) error
+	// Resolve は通報をロックして decide に渡し、返された対応を適用して閉じる
+	// 通報がなければ ErrNotFound、停止する持ち主がいなければ ErrMissingReference
+	Resolve(ctx context.Context, id, adminID, note string, decide func(Report) (Moderation, error)) error
+}
+
+// StatsSummary はユーザーの通算の数
+type StatsSummary struct {
+	Matches         int
+	ReviewsSent     int
+	ReviewsReceived int
+	// AvgResponseSeconds はレビューを受け取ってから返すまでの平均秒数（返したことがなければ nil）
+	AvgResponseSeconds *float64
+}
+
+// WorkSwipes は作品ごとのいいね・パスの数
+type WorkSwipes struct {
+	WorkID string
+	Title  string
+	Likes  int
+	Passes int
+}
+
+// DailyCounts は 1 日（UTC）分の数
+type DailyCounts struct {
+	Day             time.Time
+	Likes           int
+	Passes          int
+	Matches         int
+	ReviewsSent     int
+	ReviewsReceived int
+}
+
+type Stats interface {
+	Summary(ctx context.Context, userID string) (StatsSummary, error)
+	// WorkSwipes は userID の作品ごとの数を新しい作品から返す
+	WorkSwipes(ctx context.Context, userID string) ([]WorkSwipes, error)
+	// Daily は今日（UTC）までの days 日分を古い日から返す
+	Daily(ctx context.Context, userID string, days int) ([]DailyCounts, error)
+}
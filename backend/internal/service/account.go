@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+	"github.com/p2hacks2025/pre-12/backend/internal/storage"
+)
+
+// AccountDeletionGrace は退会申請から完全に削除するまでの猶予期間（この間は取り消せる）
+const AccountDeletionGrace = 30 * 24 * time.Hour
+
+// defaultIconObject は全員で共有している既定のアイコン（消してはいけない）
+const defaultIconObject = "icons/default.png"
+
+// PurgeDeletedAccounts は猶予期間を過ぎた退会ユーザーを完全に削除し、削除した人数を返す
+func PurgeDeletedAccounts(ctx context.Context, accounts repository.Accounts, store storage.Config) (int, error) {
+	userIDs, err := accounts.DueForPurge(ctx, time.Now().Add(-AccountDeletionGrace))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, id := range userIDs {
+		if err := PurgeAccount(ctx, accounts, store, id); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+// PurgeAccount はユーザーを完全に削除する
+//   - 他の人に書いたレビューは匿名化して残す（編集履歴は消す）
+//   - それ以外のデータも消える
+//   - アイコンと作品の画像は store から消す
+func PurgeAccount(ctx context.Context, accounts repository.Accounts, store storage.Config, userID string) error {
+	objects, err := accounts.Purge(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// 取り消された・既に削除された
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// ストレージの画像は DB から消したあとで消す（残っても参照されない）
+	for _, obj := range objects {
+		if obj == defaultIconObject {
+			continue
+		}
+		if _, _, ok := storage.SplitObjectPath(obj); !ok {
+			continue
+		}
+		if err := store.DeleteObject(ctx, obj); err != nil {
+			log.Printf("failed to delete object %s of purged user %s: %v", obj, userID, err)
+		}
+	}
+
+	return nil
+}
+
+// RunAccountPurge は interval ごとに PurgeDeletedAccounts を実行する
+func RunAccountPurge(ctx context.Context, accounts repository.Accounts, store storage.Config, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := PurgeDeletedAccounts(ctx, accounts, store); err != nil {
+			log.Printf("account purge failed: %v", err)
+		} else if n > 0 {
+			log.Printf("purged %d deleted accounts", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
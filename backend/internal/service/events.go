@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+)
+
+// notifyMatchCreated はマッチした 2 人それぞれに match.created を配信し、受信箱にも残す
+// work1 は user1 の作品、work2 は user2 の作品
+func (n *Notifier) notifyMatchCreated(ctx context.Context, matchID, user1, user2, work1, work2 string) {
+	realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventMatchCreated, user1, map[string]any{
+		"match_id":   matchID,
+		"user_id":    user2,
+		"work_id":    work2,
+		"my_work_id": work1,
+	}))
+	realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventMatchCreated, user2, map[string]any{
+		"match_id":   matchID,
+		"user_id":    user1,
+		"work_id":    work1,
+		"my_work_id": work2,
+	}))
+
+	n.Notify(ctx, Notification{
+		UserID:      user1,
+		Type:        NotificationMatchCreated,
+		ActorUserID: user2,
+		MatchID:     matchID,
+	})
+	n.Notify(ctx, Notification{
+		UserID:      user2,
+		Type:        NotificationMatchCreated,
+		ActorUserID: user1,
+		MatchID:     matchID,
+	})
+}
+
+// NotifyReviewPosted はレビュー投稿後のイベントを配信し、受信箱にも残す
+//   - 受信者には review.received（自分がまだレビューしていなければロック中）
+//   - 受信者が既にレビュー済みなら、投稿者側のロックが外れるので投稿者に review.unlocked
+func (n *Notifier) NotifyReviewPosted(ctx context.Context, reviewID, matchID, fromUserID, toUserID string) {
+	// 相手（受信者）が既に投稿者へレビューしているか
+	counterpartReviewID, err := n.Reviews.FindID(ctx, matchID, toUserID)
+	counterpartReviewed := err == nil
+
+	realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventReviewReceived, toUserID, map[string]any{
+		"review_id": reviewID,
+		"match_id":  matchID,
+		"user_id":   fromUserID,
+		"is_locked": !counterpartReviewed,
+	}))
+	n.Notify(ctx, Notification{
+		UserID:      toUserID,
+		Type:        NotificationReviewReceived,
+		ActorUserID: fromUserID,
+		MatchID:     matchID,
+		ReviewID:    reviewID,
+	})
+
+	if counterpartReviewed {
+		realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventReviewUnlocked, fromUserID, map[string]any{
+			"review_id": counterpartReviewID,
+			"match_id":  matchID,
+			"user_id":   toUserID,
+		}))
+		n.Notify(ctx, Notification{
+			UserID:      fromUserID,
+			Type:        NotificationReviewUnlocked,
+			ActorUserID: toUserID,
+			MatchID:     matchID,
+			ReviewID:    counterpartReviewID,
+		})
+	}
+}
+
+// NotifyReviewRead は受信者がレビューを読んだことを投稿者に配信する
+func (n *Notifier) NotifyReviewRead(ctx context.Context, reviewID, matchID, readerID, reviewerID string) {
+	realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventReviewRead, reviewerID, map[string]any{
+		"review_id": reviewID,
+		"match_id":  matchID,
+		"user_id":   readerID,
+	}))
+}
+
+// NotifyReviewReacted は受信者からのリアクションを投稿者に配信し、受信箱にも残す
+func (n *Notifier) NotifyReviewReacted(ctx context.Context, reviewID, matchID, reactorID, reviewerID, reaction string) {
+	realtime.Publish(ctx, n.Broker, realtime.NewEvent(realtime.EventReviewReacted, reviewerID, map[string]any{
+		"review_id": reviewID,
+		"match_id":  matchID,
+		"user_id":   reactorID,
+		"reaction":  reaction,
+	}))
+	n.Notify(ctx, Notification{
+		UserID:      reviewerID,
+		Type:        NotificationReviewReacted,
+		ActorUserID: reactorID,
+		MatchID:     matchID,
+		ReviewID:    reviewID,
+	})
+}
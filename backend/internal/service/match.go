@@ -2,50 +2,56 @@ package service
 
 import (
 	"context"
+	"errors"
 
-	"github.com/p2hacks2025/pre-12/backend/internal/db"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
 )
 
-// CheckAndCreateMatch はマッチ判定を行い、成立すれば matches に保存する
-// goroutine から呼ばれる前提
-func CheckAndCreateMatch(fromUserID, toWorkID string) {
-	ctx := context.Background()
+// Matcher はいいねからマッチを判定する
+type Matcher struct {
+	Works    repository.Works
+	Swipes   repository.Swipes
+	Matches  repository.Matches
+	Notifier *Notifier
+}
+
+func NewMatcher(repos repository.Repositories, notifier *Notifier) *Matcher {
+	return &Matcher{Works: repos.Works, Swipes: repos.Swipes, Matches: repos.Matches, Notifier: notifier}
+}
 
+// CheckAndCreateMatch はマッチ判定を行い、成立すれば matches に保存して 2 人に通知する
+// 相手がまだいいねしていない・既にマッチ済みなら false
+func (m *Matcher) CheckAndCreateMatch(ctx context.Context, fromUserID, toWorkID string) (bool, error) {
 	// 作品の作者IDを取得
-	var toWorkUserID string
-	err := db.Pool.QueryRow(ctx,
-		`SELECT user_id FROM works WHERE id=$1`, toWorkID,
-	).Scan(&toWorkUserID)
+	toWorkUserID, err := m.Works.Owner(ctx, toWorkID)
 	if err != nil {
-		return
+		return false, err
 	}
 
 	// 相手が自分の作品にいいねしているか
-	var otherWorkID string
-	err = db.Pool.QueryRow(ctx, `
-		SELECT to_work_id
-		FROM swipes
-		WHERE from_user_id=$1
-		  AND to_work_user_id=$2
-		  AND is_like=true
-	`, toWorkUserID, fromUserID).Scan(&otherWorkID)
-
+	otherWorkID, err := m.Swipes.FindLike(ctx, toWorkUserID, fromUserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
 	if err != nil {
-		return
+		return false, err
 	}
 
-	// user_id の順序を固定
-	user1, user2 := fromUserID, toWorkUserID
-	work1, work2 := toWorkID, otherWorkID
-	if user2 < user1 {
-		user1, user2 = user2, user1
-		work1, work2 = work2, work1
+	// マッチ作成（重複は無視）
+	match, err := m.Matches.Create(ctx, repository.Match{
+		User1ID: fromUserID,
+		User2ID: toWorkUserID,
+		Work1ID: toWorkID,
+		Work2ID: otherWorkID,
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		// 既にマッチ済みなら通知もしない
+		return false, nil
+	}
+	if err != nil {
+		return false, err
 	}
 
-	// マッチ作成（重複は無視）
-	_, _ = db.Pool.Exec(ctx, `
-		INSERT INTO matches (user1_id, user2_id, work1_id, work2_id)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT DO NOTHING
-	`, user1, user2, work1, work2)
+	m.Notifier.notifyMatchCreated(ctx, match.ID, match.User1ID, match.User2ID, match.Work1ID, match.Work2ID)
+	return true, nil
 }
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+// 通報の対象
+const (
+	ReportTargetReview = "review"
+	ReportTargetWork   = "work"
+	ReportTargetUser   = "user"
+)
+
+// 通報の理由
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonInappropriate = "inappropriate"
+	ReportReasonImpersonation = "impersonation"
+	ReportReasonCopyright     = "copyright"
+	ReportReasonOther         = "other"
+)
+
+// ReportReasons は通報画面に出す理由の一覧
+var ReportReasons = []string{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonInappropriate,
+	ReportReasonImpersonation,
+	ReportReasonCopyright,
+	ReportReasonOther,
+}
+
+// 通報の状態
+const (
+	ReportStatusOpen      = "open"      // 未確認
+	ReportStatusTriaged   = "triaged"   // 確認中
+	ReportStatusResolved  = "resolved"  // 対応済み
+	ReportStatusDismissed = "dismissed" // 対応不要
+)
+
+// 通報への対応
+const (
+	ModerationNone        = "none"
+	ModerationHideReview  = "hide_review"
+	ModerationHideWork    = "hide_work"
+	ModerationSuspendUser = "suspend_user"
+)
+
+var (
+	ErrTargetNotFound  = errors.New("target not found")
+	ErrInvalidAction   = errors.New("action does not apply to this target")
+	ErrReportNotFound  = errors.New("report not found")
+	ErrReportFinalized = errors.New("report already resolved")
+)
+
+func IsReportTarget(t string) bool {
+	return t == ReportTargetReview || t == ReportTargetWork || t == ReportTargetUser
+}
+
+func IsReportReason(r string) bool {
+	for _, v := range ReportReasons {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+func IsModerationAction(a string) bool {
+	switch a {
+	case ModerationNone, ModerationHideReview, ModerationHideWork, ModerationSuspendUser:
+		return true
+	}
+	return false
+}
+
+func IsReportStatus(s string) bool {
+	switch s {
+	case ReportStatusOpen, ReportStatusTriaged, ReportStatusResolved, ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+// TargetOwner は通報対象の持ち主（レビューの投稿者・作品の作者・ユーザー本人）を返す
+// 退会などで持ち主がいないときも ErrTargetNotFound
+func TargetOwner(ctx context.Context, reports repository.Reports, targetType, targetID string) (string, error) {
+	ownerID, err := reports.TargetOwner(ctx, targetType, targetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return "", ErrTargetNotFound
+	}
+	return ownerID, err
+}
+
+// IsAdmin は管理者かどうかを返す（停止中の管理者は使えない）
+func IsAdmin(ctx context.Context, users repository.Users, userID string) bool {
+	u, err := users.Get(ctx, userID)
+	return err == nil && u.IsAdmin && u.SuspendedAt == nil
+}
+
+// ResolveReport は通報に対応を適用して閉じる
+// action が none なら対応不要（dismissed）として閉じる
+func ResolveReport(ctx context.Context, reports repository.Reports, reportID, adminID, action, note string) error {
+	err := reports.Resolve(ctx, reportID, adminID, note, func(r repository.Report) (repository.Moderation, error) {
+		if r.Status == ReportStatusResolved || r.Status == ReportStatusDismissed {
+			return repository.Moderation{}, ErrReportFinalized
+		}
+
+		mod := repository.Moderation{Status: ReportStatusResolved, Action: action}
+		switch {
+		case action == ModerationNone:
+			mod.Status = ReportStatusDismissed
+		case action == ModerationHideReview && r.TargetType == ReportTargetReview:
+			mod.HideTarget = true
+		case action == ModerationHideWork && r.TargetType == ReportTargetWork:
+			mod.HideTarget = true
+		case action == ModerationSuspendUser:
+			// レビューや作品の通報でも、その持ち主を停止できる
+			mod.SuspendOwner = true
+		default:
+			return repository.Moderation{}, ErrInvalidAction
+		}
+		return mod, nil
+	})
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrReportNotFound
+	case errors.Is(err, repository.ErrMissingReference):
+		return ErrTargetNotFound
+	}
+	return err
+}
+
+// CreateSystemReport はシステムが見つけた問題を通報として残す（reporter_id は null）
+func CreateSystemReport(ctx context.Context, reports repository.Reports, targetType, targetID, reason, note string) {
+	_, err := reports.Create(ctx, repository.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		Note:       note,
+	})
+	if err != nil {
+		log.Printf("failed to create system report (%s %s): %v", targetType, targetID, err)
+	}
+}
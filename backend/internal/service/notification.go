@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+	"github.com/p2hacks2025/pre-12/backend/internal/realtime"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+// 通知種別（リアルタイムイベントと同じ名前を使う）
+const (
+	NotificationMatchCreated   = "match.created"
+	NotificationReviewReceived = "review.received"
+	NotificationReviewUnlocked = "review.unlocked"
+	NotificationReviewExpiring = "review.expiring"
+	NotificationReviewReacted  = "review.reacted"
+)
+
+// NotificationTypes は設定画面に出す通知種別の一覧
+var NotificationTypes = []string{
+	NotificationMatchCreated,
+	NotificationReviewReceived,
+	NotificationReviewUnlocked,
+	NotificationReviewExpiring,
+	NotificationReviewReacted,
+}
+
+// IsNotificationType は通知種別として有効か判定する
+func IsNotificationType(t string) bool {
+	for _, nt := range NotificationTypes {
+		if nt == t {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// ReviewDeadline はマッチ成立からレビューを書くまでの期限
+	ReviewDeadline = 7 * 24 * time.Hour
+	// ReviewReminderBefore は期限の何時間前にリマインドするか
+	ReviewReminderBefore = 24 * time.Hour
+)
+
+// Notification は受信箱に追加する通知
+// 関連しない ID は空文字のままでよい
+type Notification struct {
+	UserID      string
+	Type        string
+	ActorUserID string
+	MatchID     string
+	ReviewID    string
+}
+
+// Notifier は受信箱に通知を残し、端末にもプッシュする
+type Notifier struct {
+	Notifications repository.Notifications
+	Users         repository.Users
+	Reviews       repository.Reviews
+
+	// Broker はアプリを開いている端末へのイベント配信
+	Broker realtime.Broker
+
+	// Push が nil ならプッシュはしない
+	Push *notify.Dispatcher
+}
+
+func NewNotifier(repos repository.Repositories, broker realtime.Broker) *Notifier {
+	return &Notifier{Notifications: repos.Notifications, Users: repos.Users, Reviews: repos.Reviews, Broker: broker}
+}
+
+// Notify は通知設定を確認したうえで受信箱に通知を追加し、端末にもプッシュする
+// 通知をオフにしている種別は保存もプッシュもしない
+func (n *Notifier) Notify(ctx context.Context, note Notification) {
+	created, err := n.Notifications.Create(ctx, repository.Notification(note))
+	if err != nil {
+		log.Printf("failed to create notification (%s): %v", note.Type, err)
+		return
+	}
+
+	if created {
+		n.push(ctx, note)
+	}
+}
+
+// RemindExpiringReviews はレビュー期限が近いのに未レビューのマッチについて通知を作る
+func (n *Notifier) RemindExpiringReviews(ctx context.Context) {
+	now := time.Now()
+	remindFrom := now.Add(-(ReviewDeadline - ReviewReminderBefore))
+	expiredAt := now.Add(-ReviewDeadline)
+
+	created, err := n.Notifications.RemindUnreviewed(ctx, NotificationReviewExpiring, expiredAt, remindFrom)
+	if err != nil {
+		log.Printf("failed to create review reminders: %v", err)
+		return
+	}
+
+	if len(created) > 0 {
+		log.Printf("created %d review reminders", len(created))
+	}
+	for _, note := range created {
+		n.push(ctx, Notification(note))
+	}
+}
+
+// RunReviewReminder は interval ごとに RemindExpiringReviews を実行する
+// ctx がキャンセルされるまで戻らない
+func (n *Notifier) RunReviewReminder(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n.RemindExpiringReviews(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
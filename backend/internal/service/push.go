@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/notify"
+)
+
+// push は受信箱に追加した通知を端末にもプッシュする
+// Push が未設定ならなにもしない
+func (n *Notifier) push(ctx context.Context, note Notification) {
+	if n.Push == nil {
+		return
+	}
+
+	// 相手の名前を本文に入れる（取れなければ汎用の呼び方にする）
+	actor := "マッチした相手"
+	if note.ActorUserID != "" {
+		if u, err := n.Users.Get(ctx, note.ActorUserID); err == nil {
+			actor = u.Username + "さん"
+		}
+	}
+
+	title, body := pushText(note.Type, actor)
+
+	data := map[string]string{"type": note.Type}
+	if note.MatchID != "" {
+		data["match_id"] = note.MatchID
+	}
+	if note.ReviewID != "" {
+		data["review_id"] = note.ReviewID
+	}
+
+	err := n.Push.NotifyUser(ctx, note.UserID, notify.Notification{
+		Title: title,
+		Body:  body,
+		Data:  data,
+	})
+	if err != nil {
+		log.Printf("failed to push notification (%s): %v", note.Type, err)
+	}
+}
+
+// pushText は通知種別ごとのタイトルと本文を返す
+func pushText(notificationType, actor string) (string, string) {
+	switch notificationType {
+	case NotificationMatchCreated:
+		return "マッチしました", actor + "とマッチしました。キラットを届けてみましょう"
+	case NotificationReviewReceived:
+		return "キラットが届きました", actor + "からレビューが届きました"
+	case NotificationReviewUnlocked:
+		return "キラットを読めるようになりました", actor + "からのレビューが開封できます"
+	case NotificationReviewExpiring:
+		return "レビューの期限が近づいています", actor + "へのレビュー期限まであと少しです"
+	case NotificationReviewReacted:
+		return "キラットが届きました", actor + "があなたのレビューにリアクションしました"
+	default:
+		return "Kiratto", "新しいお知らせがあります"
+	}
+}
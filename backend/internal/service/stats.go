@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/p2hacks2025/pre-12/backend/internal/cache"
+	"github.com/p2hacks2025/pre-12/backend/internal/repository"
+)
+
+const (
+	// StatsCacheTTL は集計結果を使い回す時間
+	StatsCacheTTL = 5 * time.Minute
+	// DefaultStatsDays / MaxStatsDays は日別推移の日数
+	DefaultStatsDays = 30
+	MaxStatsDays     = 90
+)
+
+// WorkStats は作品ごとの反応
+type WorkStats struct {
+	WorkID   string   `json:"work_id"`
+	Title    string   `json:"title"`
+	Likes    int      `json:"likes"`
+	Passes   int      `json:"passes"`
+	LikeRate *float64 `json:"like_rate"` // スワイプされていなければ null
+}
+
+// DailyStats は 1 日分の推移（日付は UTC）
+type DailyStats struct {
+	Date            string   `json:"date"`
+	Likes           int      `json:"likes"`
+	Passes          int      `json:"passes"`
+	LikeRate        *float64 `json:"like_rate"`
+	Matches         int      `json:"matches"`
+	ReviewsSent     int      `json:"reviews_sent"`
+	ReviewsReceived int      `json:"reviews_received"`
+}
+
+// UserStats はクリエイター向けダッシュボードの集計
+type UserStats struct {
+	Likes           int      `json:"likes"`
+	Passes          int      `json:"passes"`
+	LikeRate        *float64 `json:"like_rate"`
+	Matches         int      `json:"matches"`
+	ReviewsSent     int      `json:"reviews_sent"`
+	ReviewsReceived int      `json:"reviews_received"`
+	// AvgResponseSeconds はレビューを受け取ってから返すまでの平均秒数（返したことがなければ null）
+	AvgResponseSeconds *float64     `json:"avg_response_seconds"`
+	Works              []WorkStats  `json:"works"`
+	Daily              []DailyStats `json:"daily"`
+	GeneratedAt        time.Time    `json:"generated_at"`
+}
+
+var statsCache = cache.NewTTL[string, *UserStats](StatsCacheTTL)
+
+// GetUserStats はキャッシュがあればそれを、なければ集計して返す
+func GetUserStats(ctx context.Context, stats repository.Stats, userID string, days int) (*UserStats, error) {
+	key := fmt.Sprintf("%s:%d", userID, days)
+	if s, ok := statsCache.Get(key); ok {
+		return s, nil
+	}
+
+	s, err := ComputeUserStats(ctx, stats, userID, days)
+	if err != nil {
+		return nil, err
+	}
+	statsCache.Set(key, s)
+	return s, nil
+}
+
+// ComputeUserStats は swipes / matches / reviews から集計する
+func ComputeUserStats(ctx context.Context, stats repository.Stats, userID string, days int) (*UserStats, error) {
+	s := &UserStats{Works: []WorkStats{}, Daily: []DailyStats{}, GeneratedAt: time.Now().UTC()}
+
+	summary, err := stats.Summary(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.Matches = summary.Matches
+	s.ReviewsSent = summary.ReviewsSent
+	s.ReviewsReceived = summary.ReviewsReceived
+	s.AvgResponseSeconds = summary.AvgResponseSeconds
+
+	works, err := stats.WorkSwipes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range works {
+		s.Likes += w.Likes
+		s.Passes += w.Passes
+		s.Works = append(s.Works, WorkStats{
+			WorkID:   w.WorkID,
+			Title:    w.Title,
+			Likes:    w.Likes,
+			Passes:   w.Passes,
+			LikeRate: likeRate(w.Likes, w.Passes),
+		})
+	}
+	s.LikeRate = likeRate(s.Likes, s.Passes)
+
+	daily, err := stats.Daily(ctx, userID, days)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range daily {
+		s.Daily = append(s.Daily, DailyStats{
+			Date:            d.Day.UTC().Format("2006-01-02"),
+			Likes:           d.Likes,
+			Passes:          d.Passes,
+			LikeRate:        likeRate(d.Likes, d.Passes),
+			Matches:         d.Matches,
+			ReviewsSent:     d.ReviewsSent,
+			ReviewsReceived: d.ReviewsReceived,
+		})
+	}
+
+	return s, nil
+}
+
+func likeRate(likes, passes int) *float64 {
+	if likes+passes == 0 {
+		return nil
+	}
+	r := float64(likes) / float64(likes+passes)
+	return &r
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // UploadLocalFileToSupabase はローカル画像ファイルを Supabase Storage にアップロードする
-func UploadLocalFileToSupabase(
+func (c Config) UploadLocalFileToSupabase(
 	ctx context.Context,
 	file *os.File,
 	bucket string,
@@ -27,19 +27,14 @@ func UploadLocalFileToSupabase(
 		contentType = "image/gif"
 	}
 
-	url := fmt.Sprintf(
-		"%s/storage/v1/object/%s/%s",
-		os.Getenv("SUPABASE_URL"),
-		bucket,
-		path,
-	)
+	url := c.objectURL(bucket, path)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, file)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
+	req.Header.Set("Authorization", "Bearer "+c.ServiceRoleKey)
 	req.Header.Set("Content-Type", contentType)
 
 	res, err := http.DefaultClient.Do(req)
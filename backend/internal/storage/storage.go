@@ -0,0 +1,19 @@
+package storage
+
+import "fmt"
+
+// Config は Supabase Storage の接続先
+// アップロードや削除はこの接続先のメソッドとして呼ぶ（起動時に設定から作って渡す）
+type Config struct {
+	URL            string // https://xxxx.supabase.co
+	ServiceRoleKey string
+}
+
+func (c Config) objectURL(bucket, path string) string {
+	return fmt.Sprintf("%s/storage/v1/object/%s/%s", c.URL, bucket, path)
+}
+
+// PublicURL は公開バケットの "bucket/path" を誰でも読める URL にする
+func (c Config) PublicURL(path string) string {
+	return fmt.Sprintf("%s/storage/v1/object/public/%s", c.URL, path)
+}
@@ -7,13 +7,13 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
+	"strings"
 )
 
 // UploadToSupabase は
 // multipart/form-data で受け取った画像ファイルを
 // 指定された Supabase Storage バケットにアップロードする関数
-func UploadToSupabase(
+func (c Config) UploadToSupabase(
 	ctx context.Context, // リクエストの寿命・キャンセル管理用（Gin から渡す）
 	fileHeader *multipart.FileHeader, // フォームで送られてきた画像ファイルのメタ情報
 	bucket string, // バケット名（例: works, icons）
@@ -35,12 +35,7 @@ func UploadToSupabase(
 
 	// ③ Supabase Storage のアップロード先 URL を作る
 	// /storage/v1/object/{bucket}/{path}
-	url := fmt.Sprintf(
-		"%s/storage/v1/object/%s/%s",
-		os.Getenv("SUPABASE_URL"), // https://xxxx.supabase.co
-		bucket,                    // 指定バケット
-		path,                      // バケット内のパス
-	)
+	url := c.objectURL(bucket, path)
 
 	// ④ HTTP POST リクエストを作成
 	req, err := http.NewRequestWithContext(
@@ -56,7 +51,7 @@ func UploadToSupabase(
 	// ⑤ 認証ヘッダー
 	req.Header.Set(
 		"Authorization",
-		"Bearer "+os.Getenv("SUPABASE_SERVICE_ROLE_KEY"),
+		"Bearer "+c.ServiceRoleKey,
 	)
 
 	// ⑥ Content-Type を元ファイルのものに合わせる
@@ -82,15 +77,15 @@ func UploadToSupabase(
 }
 
 // DeleteFromSupabase は指定バケット内のファイルを削除する
-func DeleteFromSupabase(ctx context.Context, bucket, path string) error {
-	url := fmt.Sprintf("%s/storage/v1/object/%s/%s", os.Getenv("SUPABASE_URL"), bucket, path)
+func (c Config) DeleteFromSupabase(ctx context.Context, bucket, path string) error {
+	url := c.objectURL(bucket, path)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
+	req.Header.Set("Authorization", "Bearer "+c.ServiceRoleKey)
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -104,3 +99,50 @@ func DeleteFromSupabase(ctx context.Context, bucket, path string) error {
 
 	return nil
 }
+
+// SplitObjectPath は DB に保存している "bucket/path" 形式のパスをバケット名とバケット内のパスに分ける
+func SplitObjectPath(objectPath string) (string, string, bool) {
+	bucket, path, ok := strings.Cut(objectPath, "/")
+	if !ok || bucket == "" || path == "" {
+		return "", "", false
+	}
+	return bucket, path, true
+}
+
+// DeleteObject は "bucket/path" 形式のパスのファイルを削除する
+func (c Config) DeleteObject(ctx context.Context, objectPath string) error {
+	bucket, path, ok := SplitObjectPath(objectPath)
+	if !ok {
+		return fmt.Errorf("invalid object path: %s", objectPath)
+	}
+	return c.DeleteFromSupabase(ctx, bucket, path)
+}
+
+// DownloadObject は "bucket/path" 形式のパスのファイルを取得する（非公開バケットも読める）
+func (c Config) DownloadObject(ctx context.Context, objectPath string) ([]byte, error) {
+	bucket, path, ok := SplitObjectPath(objectPath)
+	if !ok {
+		return nil, fmt.Errorf("invalid object path: %s", objectPath)
+	}
+
+	url := c.objectURL(bucket, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.ServiceRoleKey)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, fmt.Errorf("download failed: %s", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
@@ -0,0 +1,272 @@
+package textfilter
+
+import (
+	"bufio"
+	"embed"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+//go:embed words/*.txt
+var wordFiles embed.FS
+
+// Mode は禁止語を含むテキストの扱い
+type Mode string
+
+const (
+	ModeReject Mode = "reject" // 保存を拒否する
+	ModeMask   Mode = "mask"   // 禁止語を伏せ字にして保存し、モデレーション用に通報を残す
+)
+
+// MaskRune は伏せ字に使う文字
+const MaskRune = '*'
+
+type word struct {
+	text  string
+	norm  []rune
+	ascii bool // 英語は単語の途中（class の ass など）に一致させない
+	whole bool // 前後が文字でなく、間に記号を挟まないときだけ一致させる（「=しね」と書く）
+}
+
+// WholePrefix を付けた語は単独の語としてだけ一致させる
+// 「しね」「くず」のような短いかなは「少しねじれた」「くずれる」にも含まれるため
+const WholePrefix = "="
+
+// Filter は禁止語リストでテキストを検査する
+type Filter struct {
+	words []word
+}
+
+// New は禁止語リストから Filter を作る
+func New(words ...string) *Filter {
+	f := &Filter{}
+	f.Add(words...)
+	return f
+}
+
+// Add は禁止語を追加する（WholePrefix で始まる語は単独の語としてだけ一致させる）
+func (f *Filter) Add(words ...string) {
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		whole := strings.HasPrefix(w, WholePrefix)
+		w = strings.TrimSpace(strings.TrimPrefix(w, WholePrefix))
+		norm, _ := normalize(w)
+		if len(norm) == 0 {
+			continue
+		}
+		f.words = append(f.words, word{text: w, norm: norm, ascii: isASCII(w), whole: whole})
+	}
+}
+
+// NewDefault は埋め込みの日本語・英語リストを読み込んだ Filter を作る
+func NewDefault() *Filter {
+	f := New()
+	for _, name := range []string{"words/ja.txt", "words/en.txt"} {
+		b, err := wordFiles.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+		f.Add(strings.Split(string(b), "\n")...)
+	}
+	return f
+}
+
+// LoadFile は 1 行 1 語のファイルから禁止語を追加する（運用で語を足すため）
+func (f *Filter) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		f.Add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Match は見つかった禁止語と、元のテキスト上の位置（rune 単位、End は含まない）
+type Match struct {
+	Word  string
+	Start int
+	End   int
+}
+
+// Find はテキスト中の禁止語を探す
+func (f *Filter) Find(text string) []Match {
+	original := []rune(text)
+	norm, index := normalize(text)
+
+	var matches []Match
+	for _, w := range f.words {
+		for i := 0; i+len(w.norm) <= len(norm); i++ {
+			if !equalRunes(norm[i:i+len(w.norm)], w.norm) {
+				continue
+			}
+			start, end := index[i], index[i+len(w.norm)-1]+1
+			// 合成した半角の濁点・半濁点（ｸｽﾞ の ﾞ）も一致した範囲に含める
+			for end < len(original) && isVoicedMark(foldRune(original[end])) {
+				end++
+			}
+			if w.ascii && (isWordRune(original, start-1) || isWordRune(original, end)) {
+				continue
+			}
+			if w.whole && !isWholeToken(original, start, end) {
+				continue
+			}
+			matches = append(matches, Match{Word: w.text, Start: start, End: end})
+		}
+	}
+	return matches
+}
+
+// Contains は禁止語を含むか判定する
+func (f *Filter) Contains(text string) bool {
+	return len(f.Find(text)) > 0
+}
+
+// Mask は禁止語を伏せ字にしたテキストと、伏せ字にしたかどうかを返す
+// 空白は伏せ字にしない（「f u c k」は「* * * *」になる）
+func (f *Filter) Mask(text string) (string, bool) {
+	matches := f.Find(text)
+	if len(matches) == 0 {
+		return text, false
+	}
+
+	runes := []rune(text)
+	for _, m := range matches {
+		for i := m.Start; i < m.End; i++ {
+			if !unicode.IsSpace(runes[i]) {
+				runes[i] = MaskRune
+			}
+		}
+	}
+	return string(runes), true
+}
+
+// Normalize は比較用の正規化結果を返す
+//   - 全角英数・半角カナの幅をそろえる
+//   - カタカナをひらがなにする
+//   - 小文字にする
+//   - 半角カナの濁点・半濁点を合成する
+//   - 空白・記号を取り除く
+func Normalize(s string) string {
+	norm, _ := normalize(s)
+	return string(norm)
+}
+
+// normalize は正規化した rune 列と、その各 rune が元のテキストの何文字目かを返す
+func normalize(s string) ([]rune, []int) {
+	var (
+		out   []rune
+		index []int
+	)
+	for i, r := range []rune(s) {
+		for _, fr := range width.Fold.String(string(r)) {
+			fr = unicode.ToLower(fr)
+			if fr >= 'ァ' && fr <= 'ヶ' {
+				fr -= 'ァ' - 'ぁ'
+			}
+			// 半角カナの濁点・半濁点は直前の文字と合成する（ｶﾞ → が）
+			if isVoicedMark(fr) {
+				if n := len(out); n > 0 {
+					if composed := []rune(norm.NFC.String(string(out[n-1]) + string(toCombining(fr)))); len(composed) == 1 {
+						out[n-1] = composed[0]
+					}
+				}
+				continue
+			}
+			if skipRune(fr) {
+				continue
+			}
+			out = append(out, fr)
+			index = append(index, i)
+		}
+	}
+	return out, index
+}
+
+// foldRune は幅をそろえた rune を返す
+func foldRune(r rune) rune {
+	return []rune(width.Fold.String(string(r)))[0]
+}
+
+func isVoicedMark(r rune) bool {
+	switch r {
+	case '\u3099', '\u309A', '゛', '゜':
+		return true
+	}
+	return false
+}
+
+// toCombining は濁点・半濁点を結合文字にする
+func toCombining(r rune) rune {
+	switch r {
+	case '゛':
+		return '\u3099'
+	case '゜':
+		return '\u309A'
+	}
+	return r
+}
+
+func skipRune(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// isWholeToken は runes[start:end] が前後を文字に挟まれず、間に空白・記号も含まない語かを返す
+func isWholeToken(runes []rune, start, end int) bool {
+	if isLetterRune(runes, start-1) || isLetterRune(runes, end) {
+		return false
+	}
+	for i := start; i < end; i++ {
+		if !isLetterRune(runes, i) && !isVoicedMark(foldRune(runes[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLetterRune は runes[i] がかな・漢字・英数字などの文字かを返す（範囲外なら false）
+func isLetterRune(runes []rune, i int) bool {
+	if i < 0 || i >= len(runes) {
+		return false
+	}
+	r := runes[i]
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isWordRune は runes[i] が英数字かを返す（範囲外なら false）
+func isWordRune(runes []rune, i int) bool {
+	if i < 0 || i >= len(runes) {
+		return false
+	}
+	r := foldRune(runes[i])
+	return r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,118 @@
+package textfilter
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"ＳＨＩＴ":      "shit",
+		"ｼﾈ":        "しね",
+		"ｺﾞﾐ作品":     "ごみ作品",
+		"ガ゛":        "が",
+		"キ モ イ！":    "きもい",
+		"Hello, 世界": "hello世界",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindVariants(t *testing.T) {
+	f := New("死ね", "しね", "きもい", "ごみ作品", "shit", "kill yourself")
+
+	for _, text := range []string{
+		"お前しね",
+		"シネ",
+		"ｼﾈ",
+		"キモイ作品",
+		"ｺﾞﾐ作品だ",
+		"ＳＨＩＴ!",
+		"s h i t",
+		"just KILL YOURSELF",
+	} {
+		if !f.Contains(text) {
+			t.Errorf("expected %q to be blocked", text)
+		}
+	}
+}
+
+func TestFindRespectsEnglishWordBoundaries(t *testing.T) {
+	f := New("dick", "ass")
+
+	for _, text := range []string{"Dickens is great", "a classic piece", "passion"} {
+		if f.Contains(text) {
+			t.Errorf("expected %q not to be blocked", text)
+		}
+	}
+	if !f.Contains("what a dick.") {
+		t.Error("expected standalone word to be blocked")
+	}
+}
+
+func TestMask(t *testing.T) {
+	f := New("きもい", "shit")
+
+	got, masked := f.Mask("この絵キモイ、shit です")
+	if !masked {
+		t.Fatal("expected masked")
+	}
+	if want := "この絵***、**** です"; got != want {
+		t.Fatalf("Mask = %q, want %q", got, want)
+	}
+
+	got, masked = f.Mask("透明感がある")
+	if masked || got != "透明感がある" {
+		t.Fatalf("unexpected mask of clean text: %q", got)
+	}
+}
+
+func TestDefaultListsLoaded(t *testing.T) {
+	f := NewDefault()
+	if !f.Contains("死ね") || !f.Contains("FUCK") {
+		t.Fatal("expected default ja/en lists to be loaded")
+	}
+	if f.Contains("# 日本語の禁止語") {
+		t.Fatal("comment lines must be ignored")
+	}
+}
+
+func TestWholeTokenWords(t *testing.T) {
+	f := New("=しね", "=くず", "=うざい")
+
+	for _, text := range []string{"しね", "しね！", "お前、しね", "ｸｽﾞ", "うざい。"} {
+		if !f.Contains(text) {
+			t.Errorf("expected %q to be blocked", text)
+		}
+	}
+	for _, text := range []string{"少しねじれた", "少し、ねむい", "くずれる", "うざいなぁ"} {
+		if f.Contains(text) {
+			t.Errorf("expected %q not to be blocked", text)
+		}
+	}
+}
+
+// 既定のリストの短いかなが普通の言葉に一致しない
+func TestDefaultListFalsePositives(t *testing.T) {
+	f := NewDefault()
+	for _, text := range []string{
+		"少しねじれた構図が面白い",
+		"少し、ねむい印象",
+		"くずれるような線",
+		"星くずのような光",
+		"約束どおりの仕上がり",
+		"ぶすっとした表情の描き方",
+		"うざいくらいの情報量ではない",
+		"きもいりの新作",
+		"しねまの雰囲気",
+	} {
+		if m := f.Find(text); len(m) > 0 {
+			t.Errorf("expected %q not to be blocked, matched %+v", text, m)
+		}
+	}
+	for _, text := range []string{"しね", "クズ", "ブス！", "きもい"} {
+		if !f.Contains(text) {
+			t.Errorf("expected %q to be blocked", text)
+		}
+	}
+}
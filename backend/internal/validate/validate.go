@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID は DB の uuid 列にそのまま渡せる形か
+// 形の崩れた ID を DB まで流すと 500 になるので、先に弾く
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// エラーの項目名を Go のフィールド名ではなく JSON / フォームの名前にする
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return f.Name
+	})
+
+	// 空白だけの入力を空とみなす
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+}
+
+// Error はバインドと検証のエラーを API のエラーに変換する
+// 検証エラーは項目ごとにまとめ、JSON として読めないものは invalid_request にする
+func Error(err error) *apierror.Error {
+	var ves validator.ValidationErrors
+	if errors.As(err, &ves) {
+		fields := make([]apierror.FieldError, 0, len(ves))
+		for _, fe := range ves {
+			fields = append(fields, fieldError(fe))
+		}
+		return apierror.Validation(fields)
+	}
+
+	var te *json.UnmarshalTypeError
+	if errors.As(err, &te) && te.Field != "" {
+		return apierror.Validation([]apierror.FieldError{
+			{Field: te.Field, Reason: apierror.ReasonInvalid},
+		})
+	}
+
+	return apierror.New(apierror.CodeInvalidRequest)
+}
+
+func fieldError(fe validator.FieldError) apierror.FieldError {
+	f := apierror.FieldError{Field: fieldPath(fe), Reason: apierror.ReasonInvalid}
+
+	switch fe.Tag() {
+	case "required", "notblank":
+		f.Reason = apierror.ReasonRequired
+	case "max":
+		if fe.Kind() == reflect.String {
+			f.Reason = apierror.ReasonTooLong
+		}
+		f.Param = fe.Param()
+	case "min":
+		if fe.Kind() == reflect.String {
+			f.Reason = apierror.ReasonTooShort
+		}
+		f.Param = fe.Param()
+	case "oneof":
+		f.Param = fe.Param()
+	}
+	return f
+}
+
+// fieldPath は "preferences[0].type" のようなネストした項目名を返す（先頭の構造体名は除く）
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return fe.Field()
+}
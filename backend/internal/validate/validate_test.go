@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/p2hacks2025/pre-12/backend/internal/apierror"
+)
+
+type item struct {
+	Name string `json:"name" binding:"required,notblank"`
+}
+
+type request struct {
+	UserID string   `json:"user_id" binding:"required,uuid"`
+	Title  string   `json:"title" binding:"required,max=5"`
+	Note   string   `json:"note" binding:"min=3"`
+	Items  []item   `json:"items" binding:"dive"`
+	IDs    []string `json:"ids" binding:"dive,uuid"`
+}
+
+func bind(t *testing.T, body string) *apierror.Error {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req request
+	err := c.ShouldBindJSON(&req)
+	if err == nil {
+		return nil
+	}
+	return Error(err)
+}
+
+func TestIsUUID(t *testing.T) {
+	if !IsUUID("6f1c1a52-3f2e-4b8a-9d7e-0c5a1b2c3d4e") {
+		t.Error("expected valid uuid")
+	}
+	for _, s := range []string{"", "1", "6f1c1a52-3f2e-4b8a-9d7e-0c5a1b2c3d4", "6f1c1a52_3f2e_4b8a_9d7e_0c5a1b2c3d4e"} {
+		if IsUUID(s) {
+			t.Errorf("expected invalid uuid: %q", s)
+		}
+	}
+}
+
+func TestErrorAggregatesFields(t *testing.T) {
+	e := bind(t, `{"user_id":"abc","title":"あいうえおか","note":"ab","items":[{"name":" "}],"ids":["x"]}`)
+	if e == nil || e.Code != apierror.CodeValidationFailed {
+		t.Fatalf("expected validation error, got %+v", e)
+	}
+
+	want := []apierror.FieldError{
+		{Field: "user_id", Reason: apierror.ReasonInvalid},
+		{Field: "title", Reason: apierror.ReasonTooLong, Param: "5"},
+		{Field: "note", Reason: apierror.ReasonTooShort, Param: "3"},
+		{Field: "items[0].name", Reason: apierror.ReasonRequired},
+		{Field: "ids[0]", Reason: apierror.ReasonInvalid},
+	}
+	got, _ := e.Details.([]apierror.FieldError)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d field errors, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("field %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestErrorTypeMismatch(t *testing.T) {
+	e := bind(t, `{"user_id":1}`)
+	details, _ := e.Details.([]apierror.FieldError)
+	if e.Code != apierror.CodeValidationFailed || len(details) != 1 || details[0].Field != "user_id" {
+		t.Fatalf("unexpected error: %+v", e)
+	}
+}
+
+func TestErrorMalformedJSON(t *testing.T) {
+	if e := bind(t, `{"user_id":`); e.Code != apierror.CodeInvalidRequest {
+		t.Fatalf("unexpected code: %s", e.Code)
+	}
+}
@@ -0,0 +1,26 @@
+create table public.notifications (
+  id uuid primary key default gen_random_uuid(),
+  user_id uuid not null references public.users(id) on delete cascade,
+  type text not null,
+  actor_user_id uuid references public.users(id) on delete set null,
+  match_id uuid references public.matches(id) on delete cascade,
+  review_id uuid references public.reviews(id) on delete cascade,
+  read_at timestamp with time zone,
+  created_at timestamp with time zone default now()
+);
+
+create index notifications_user_id_created_at_idx
+  on public.notifications (user_id, created_at desc);
+
+-- レビュー期限のリマインドはマッチごとに 1 回だけ
+create unique index notifications_review_expiring_once_idx
+  on public.notifications (user_id, match_id)
+  where type = 'review.expiring';
+
+create table public.notification_preferences (
+  user_id uuid not null references public.users(id) on delete cascade,
+  type text not null,
+  enabled boolean not null default true,
+  updated_at timestamp with time zone default now(),
+  primary key (user_id, type)
+);
@@ -0,0 +1,10 @@
+create table public.device_tokens (
+  id uuid primary key default gen_random_uuid(),
+  user_id uuid not null references public.users(id) on delete cascade,
+  token text not null unique,
+  platform text not null check (platform in ('ios', 'android', 'web')),
+  created_at timestamp with time zone default now(),
+  last_seen_at timestamp with time zone default now()
+);
+
+create index device_tokens_user_id_idx on public.device_tokens (user_id);
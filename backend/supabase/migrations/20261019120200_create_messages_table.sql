@@ -0,0 +1,21 @@
+create table public.user_blocks (
+  blocker_id uuid not null references public.users(id) on delete cascade,
+  blocked_id uuid not null references public.users(id) on delete cascade,
+  created_at timestamp with time zone default now(),
+  primary key (blocker_id, blocked_id),
+  check (blocker_id <> blocked_id)
+);
+
+create index user_blocks_blocked_id_idx on public.user_blocks (blocked_id);
+
+create table public.messages (
+  id uuid primary key default gen_random_uuid(),
+  match_id uuid not null references public.matches(id) on delete cascade,
+  sender_id uuid not null references public.users(id) on delete cascade,
+  body text not null check (char_length(body) between 1 and 1000),
+  read_at timestamp with time zone,
+  created_at timestamp with time zone default now()
+);
+
+create index messages_match_id_created_at_idx
+  on public.messages (match_id, created_at desc);
@@ -0,0 +1,4 @@
+-- 観点ごとのレビュー本文と評価（[{ "aspect": "...", "body": "...", "rating": 1-5 }]）
+-- comment には旧クライアント向けに全観点をまとめた本文を入れる
+alter table public.reviews
+  add column sections jsonb not null default '[]'::jsonb;
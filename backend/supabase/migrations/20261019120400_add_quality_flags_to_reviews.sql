@@ -0,0 +1,4 @@
+-- 品質チェック（REVIEW_QUALITY_MODE=flag）で見つかった問題のコード
+-- 空でないレビューはモデレーションで確認する
+alter table public.reviews
+  add column quality_flags text[] not null default '{}';
@@ -0,0 +1,14 @@
+-- レビューの編集履歴（編集前の本文を残す）
+alter table public.reviews
+  add column edited_at timestamp with time zone;
+
+create table public.review_revisions (
+  id uuid primary key default gen_random_uuid(),
+  review_id uuid not null references public.reviews(id) on delete cascade,
+  comment text not null,
+  sections jsonb not null default '[]'::jsonb,
+  created_at timestamp with time zone not null default now()
+);
+
+create index review_revisions_review_id_idx
+  on public.review_revisions (review_id, created_at desc);
@@ -0,0 +1,11 @@
+-- 受信者がレビューを読んだ日時
+alter table public.reviews
+  add column read_at timestamp with time zone;
+
+-- 受信者からレビューへのリアクション（1 レビューにつき種類ごとに 1 回）
+create table public.review_reactions (
+  review_id uuid not null references public.reviews(id) on delete cascade,
+  reaction text not null check (reaction in ('thanks', 'helpful', 'inspired')),
+  created_at timestamp with time zone not null default now(),
+  primary key (review_id, reaction)
+);
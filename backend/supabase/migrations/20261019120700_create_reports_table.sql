@@ -0,0 +1,35 @@
+-- モデレーション用の列
+alter table public.users
+  add column is_admin boolean not null default false,
+  add column suspended_at timestamp with time zone;
+
+alter table public.works
+  add column hidden_at timestamp with time zone;
+
+alter table public.reviews
+  add column hidden_at timestamp with time zone;
+
+-- 通報（reporter_id が null のものはシステムによる自動通報）
+create table public.reports (
+  id uuid primary key default gen_random_uuid(),
+  reporter_id uuid references public.users(id) on delete set null,
+  target_type text not null check (target_type in ('review', 'work', 'user')),
+  target_id uuid not null,
+  reason text not null check (reason in ('spam', 'harassment', 'inappropriate', 'impersonation', 'copyright', 'other')),
+  note text not null default '',
+  status text not null default 'open' check (status in ('open', 'triaged', 'resolved', 'dismissed')),
+  action text check (action in ('none', 'hide_review', 'hide_work', 'suspend_user')),
+  handled_by uuid references public.users(id) on delete set null,
+  resolution_note text not null default '',
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone not null default now(),
+  resolved_at timestamp with time zone
+);
+
+create index reports_status_created_at_idx
+  on public.reports (status, created_at desc);
+
+-- 同じ人が同じ対象を未処理のまま何度も通報できないようにする
+create unique index reports_open_once_idx
+  on public.reports (reporter_id, target_type, target_id)
+  where status in ('open', 'triaged');
@@ -0,0 +1,17 @@
+-- URL に使う一意なハンドル（@handle）。username は表示名として自由に変えられる
+alter table public.users
+  add column handle text,
+  add column handle_changed_at timestamp with time zone;
+
+create unique index users_handle_key
+  on public.users (handle);
+
+-- 以前のハンドル（古い URL から今のプロフィールへ転送するため）
+create table public.handle_history (
+  handle text primary key,
+  user_id uuid not null references public.users(id) on delete cascade,
+  released_at timestamp with time zone not null default now()
+);
+
+create index handle_history_user_id_idx
+  on public.handle_history (user_id);
@@ -0,0 +1,21 @@
+-- 退会申請の日時（猶予期間のあとで完全に削除する）
+alter table public.users
+  add column deleted_at timestamp with time zone;
+
+-- 退会したユーザーが他の人に書いたレビューは消さずに匿名化する
+-- そのためマッチや投稿者、作品が消えてもレビューが残るようにする
+-- （レビューの work_id は投稿者自身の作品なので、作品の削除で一緒に消えないようにする）
+alter table public.reviews
+  add column anonymized_at timestamp with time zone,
+  alter column match_id drop not null,
+  alter column from_user_id drop not null,
+  alter column work_id drop not null,
+  drop constraint reviews_match_id_fkey,
+  drop constraint reviews_from_user_id_fkey,
+  add constraint reviews_match_id_fkey
+    foreign key (match_id) references public.matches(id) on delete set null,
+  add constraint reviews_from_user_id_fkey
+    foreign key (from_user_id) references public.users(id) on delete set null,
+  drop constraint reviews_work_id_fkey,
+  add constraint reviews_work_id_fkey
+    foreign key (work_id) references public.works(id) on delete set null;
@@ -0,0 +1,122 @@
+-- よく使うクエリのためのインデックスと、アプリ側で守っていた前提の制約
+
+-- CheckAndCreateMatch: 相手が自分の作品をいいねしているか
+create index swipes_from_user_id_to_work_user_id_like_idx
+  on public.swipes (from_user_id, to_work_user_id)
+  where is_like;
+
+-- 作品を消したときのスワイプの削除
+create index swipes_to_work_id_idx
+  on public.swipes (to_work_id);
+
+-- 自分の作品一覧（新しい順）
+create index works_user_id_created_at_idx
+  on public.works (user_id, created_at desc);
+
+-- 受信レビュー・送信レビュー（新しい順）
+create index reviews_to_user_id_created_at_idx
+  on public.reviews (to_user_id, created_at desc);
+
+create index reviews_from_user_id_created_at_idx
+  on public.reviews (from_user_id, created_at desc);
+
+-- マッチ一覧は user1_id と user2_id のどちらでも引く（user1_id は unique 制約のインデックスを使う）
+create index matches_user2_id_idx
+  on public.matches (user2_id);
+
+-- マッチは user1_id < user2_id の順で保存する
+-- 逆順のマッチは、正しい順の同じ組み合わせがあればそちらにまとめて消し、なければ入れ替える
+create temporary table match_duplicates as
+select m.id as duplicate_id, o.id as survivor_id
+from public.matches m
+join public.matches o
+  on o.user1_id = m.user2_id
+ and o.user2_id = m.user1_id
+where m.user1_id > m.user2_id;
+
+-- レビューは (match_id, from_user_id) で一意なので、同じ人のレビューが両方にあれば残す方のものを使う
+delete from public.reviews r
+using match_duplicates d
+where r.match_id = d.duplicate_id
+  and exists (
+    select 1
+    from public.reviews s
+    where s.match_id = d.survivor_id
+      and s.from_user_id = r.from_user_id
+  );
+
+-- 重複マッチを消すとレビューの match_id は null になり（Give-to-Get で読めなくなる）、
+-- メッセージと通知は消えてしまうので、先に残す方のマッチへ付け替える
+update public.reviews r
+set match_id = d.survivor_id
+from match_duplicates d
+where r.match_id = d.duplicate_id;
+
+update public.messages msg
+set match_id = d.survivor_id
+from match_duplicates d
+where msg.match_id = d.duplicate_id;
+
+-- レビュー期限のリマインドは 1 マッチにつき 1 回なので、残す方に既にあれば消す
+delete from public.notifications n
+using match_duplicates d
+where n.match_id = d.duplicate_id
+  and n.type = 'review.expiring'
+  and exists (
+    select 1
+    from public.notifications s
+    where s.match_id = d.survivor_id
+      and s.user_id = n.user_id
+      and s.type = 'review.expiring'
+  );
+
+update public.notifications n
+set match_id = d.survivor_id
+from match_duplicates d
+where n.match_id = d.duplicate_id;
+
+delete from public.matches m
+using match_duplicates d
+where m.id = d.duplicate_id;
+
+drop table match_duplicates;
+
+update public.matches
+set user1_id = user2_id,
+    user2_id = user1_id,
+    work1_id = work2_id,
+    work2_id = work1_id
+where user1_id > user2_id;
+
+alter table public.matches
+  add constraint matches_user_order_check check (user1_id < user2_id);
+
+-- 自分の作品はスワイプできない
+delete from public.swipes
+where from_user_id = to_work_user_id;
+
+alter table public.swipes
+  add constraint swipes_no_self_swipe_check check (from_user_id <> to_work_user_id);
+
+-- 自分にはレビューを書けない（匿名化されたレビューは from_user_id が null）
+alter table public.reviews
+  add constraint reviews_no_self_review_check check (from_user_id <> to_user_id);
+
+-- created_at は並び順に使うので null を許さない
+update public.works set created_at = now() where created_at is null;
+update public.swipes set created_at = now() where created_at is null;
+update public.matches set created_at = now() where created_at is null;
+update public.reviews set created_at = now() where created_at is null;
+update public.notifications set created_at = now() where created_at is null;
+update public.device_tokens set created_at = now() where created_at is null;
+update public.user_blocks set created_at = now() where created_at is null;
+update public.messages set created_at = now() where created_at is null;
+
+alter table public.works alter column created_at set not null;
+alter table public.swipes alter column created_at set not null;
+alter table public.matches alter column created_at set not null;
+alter table public.reviews alter column created_at set not null;
+alter table public.notifications alter column created_at set not null;
+alter table public.device_tokens alter column created_at set not null;
+alter table public.user_blocks alter column created_at set not null;
+alter table public.messages alter column created_at set not null;
@@ -0,0 +1,2 @@
+drop table public.notification_preferences;
+drop table public.notifications;
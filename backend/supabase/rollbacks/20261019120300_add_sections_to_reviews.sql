@@ -0,0 +1,2 @@
+alter table public.reviews
+  drop column sections;
@@ -0,0 +1,4 @@
+drop table public.review_revisions;
+
+alter table public.reviews
+  drop column edited_at;
@@ -0,0 +1,4 @@
+drop table public.review_reactions;
+
+alter table public.reviews
+  drop column read_at;
@@ -0,0 +1,11 @@
+drop table public.reports;
+
+alter table public.reviews
+  drop column hidden_at;
+
+alter table public.works
+  drop column hidden_at;
+
+alter table public.users
+  drop column suspended_at,
+  drop column is_admin;
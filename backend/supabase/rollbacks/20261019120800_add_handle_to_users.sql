@@ -0,0 +1,7 @@
+drop table public.handle_history;
+
+drop index public.users_handle_key;
+
+alter table public.users
+  drop column handle_changed_at,
+  drop column handle;
@@ -0,0 +1,17 @@
+-- 匿名化されたレビューはマッチや投稿者を持たないので、戻す前に消す
+delete from public.reviews
+where match_id is null or from_user_id is null;
+
+alter table public.reviews
+  drop constraint reviews_match_id_fkey,
+  drop constraint reviews_from_user_id_fkey,
+  add constraint reviews_match_id_fkey
+    foreign key (match_id) references public.matches(id) on delete cascade,
+  add constraint reviews_from_user_id_fkey
+    foreign key (from_user_id) references public.users(id) on delete cascade,
+  alter column match_id set not null,
+  alter column from_user_id set not null,
+  drop column anonymized_at;
+
+alter table public.users
+  drop column deleted_at;
@@ -0,0 +1,19 @@
+alter table public.works alter column created_at drop not null;
+alter table public.swipes alter column created_at drop not null;
+alter table public.matches alter column created_at drop not null;
+alter table public.reviews alter column created_at drop not null;
+alter table public.notifications alter column created_at drop not null;
+alter table public.device_tokens alter column created_at drop not null;
+alter table public.user_blocks alter column created_at drop not null;
+alter table public.messages alter column created_at drop not null;
+
+alter table public.reviews drop constraint reviews_no_self_review_check;
+alter table public.swipes drop constraint swipes_no_self_swipe_check;
+alter table public.matches drop constraint matches_user_order_check;
+
+drop index public.matches_user2_id_idx;
+drop index public.reviews_from_user_id_created_at_idx;
+drop index public.reviews_to_user_id_created_at_idx;
+drop index public.works_user_id_created_at_idx;
+drop index public.swipes_to_work_id_idx;
+drop index public.swipes_from_user_id_to_work_user_id_like_idx;
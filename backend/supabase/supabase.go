@@ -0,0 +1,11 @@
+// Package supabase はマイグレーションの SQL をバイナリに埋め込む
+// supabase CLI と同じファイルをそのまま使う
+package supabase
+
+import "embed"
+
+// Files は migrations/ と rollbacks/ の SQL
+// rollbacks/ には migrations/ と同じ名前で取り消し用の SQL を置く
+//
+//go:embed migrations/*.sql rollbacks/*.sql
+var Files embed.FS